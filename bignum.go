@@ -0,0 +1,102 @@
+package validator
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	bigIntType     = reflect.TypeOf(big.Int{})
+	bigRatType     = reflect.TypeOf(big.Rat{})
+	bigFloatType   = reflect.TypeOf(big.Float{})
+	jsonNumberType = reflect.TypeOf(json.Number(""))
+)
+
+// maxExactExponent limits decimal exponents of json.Number values compared
+// exactly, which avoids allocating huge numbers from untrusted input. Values
+// with larger exponents are compared as big.Float.
+const maxExactExponent = 1000
+
+// bigComparator returns a function comparing big numbers or json.Number
+// with number s exactly. s can be an integer, a decimal or a fraction.
+func bigComparator(s string) (func(reflect.Value) (int, bool), error) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, &ParamError{Param: s, Kind: "number"}
+	}
+	// Binary exponent of r, which is between exp-1 and exp+1.
+	exp := r.Num().BitLen() - r.Denom().BitLen()
+	return func(v reflect.Value) (int, bool) {
+		return compareBig(v, r, exp)
+	}, nil
+}
+
+// compareBig compares big number or json.Number v with r, whose binary
+// exponent is about exp. It returns false if v is not a valid number.
+func compareBig(v reflect.Value, r *big.Rat, exp int) (int, bool) {
+	if v.Type() == jsonNumberType {
+		return compareJSONNumber(v.String(), r)
+	}
+	switch x := addressOf(v).(type) {
+	case *big.Int:
+		return new(big.Rat).SetInt(x).Cmp(r), true
+	case *big.Rat:
+		return x.Cmp(r), true
+	case *big.Float:
+		if x.IsInf() {
+			return x.Sign(), true
+		}
+		return compareBigFloat(x, r, exp), true
+	}
+	return 0, false
+}
+
+// compareBigFloat compares x with r exactly. Binary exponents are compared
+// first so values far from r, e.g. 1e1000000, are not expanded to big.Rat.
+func compareBigFloat(x *big.Float, r *big.Rat, exp int) int {
+	sign := x.Sign()
+	if sign != r.Sign() || sign == 0 {
+		return compareInt(int64(sign), int64(r.Sign()))
+	}
+	// 2^(xexp-1) <= |x| < 2^xexp and 2^(exp-1) < |r| < 2^(exp+1)
+	xexp := x.MantExp(nil)
+	switch {
+	case xexp > exp+1:
+		return sign
+	case xexp < exp:
+		return -sign
+	}
+	y, _ := x.Rat(nil)
+	return y.Cmp(r)
+}
+
+func compareJSONNumber(s string, r *big.Rat) (int, bool) {
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		exp, err := strconv.Atoi(s[i+1:])
+		if err != nil || exp > maxExactExponent || exp < -maxExactExponent {
+			f, _, err := big.ParseFloat(s, 10, 64, big.ToNearestEven)
+			if err != nil {
+				return 0, false
+			}
+			return f.Cmp(new(big.Float).SetRat(r)), true
+		}
+	}
+	x, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return 0, false
+	}
+	return x.Cmp(r), true
+}
+
+// addressOf returns a pointer to v, or to a copy of v if v is not addressable.
+func addressOf(v reflect.Value) interface{} {
+	if v.CanAddr() {
+		return v.Addr().Interface()
+	}
+	p := reflect.New(v.Type())
+	p.Elem().Set(v)
+	return p.Interface()
+}
@@ -0,0 +1,133 @@
+package validator
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func bigInt(s string) *big.Int {
+	x, _ := new(big.Int).SetString(s, 10)
+	return x
+}
+
+func bigRat(s string) *big.Rat {
+	x, _ := new(big.Rat).SetString(s)
+	return x
+}
+
+func TestBigNumberBound(t *testing.T) {
+	s := struct {
+		A *big.Int    `valid:"max=18446744073709551616"`
+		B *big.Int    `valid:"max=18446744073709551616"`
+		C *big.Rat    `valid:"min=0.1"`
+		D *big.Rat    `valid:"gt=1/3"`
+		E *big.Float  `valid:"lt=0.1"`
+		F *big.Float  `valid:"max=1e100"`
+		G json.Number `valid:"between=0 0.3"`
+		H json.Number `valid:"between=0 0.3"`
+		I big.Int     `valid:"gt=0"`
+		J *big.Int    `valid:"min=1"`
+	}{
+		A: bigInt("18446744073709551616"),
+		B: bigInt("18446744073709551617"),
+		C: bigRat("1/10"),
+		D: bigRat("1/3"),
+		E: big.NewFloat(0.1),
+		F: new(big.Float).SetInf(false),
+		G: "0.3",
+		H: "0.30000000000000001",
+	}
+	err := Default().Validate(&s)
+	assertNOK(t, err,
+		"B must not be greater than 18446744073709551616 (was 18446744073709551617)",
+		"D must be greater than 1/3 (was 1/3)",
+		"E must be less than 0.1 (was 0.1)",
+		"F must not be greater than 1e100 (was +Inf)",
+		"H must be between 0 and 0.3 (was 0.30000000000000001)",
+		"I must be greater than 0 (was 0)",
+	)
+}
+
+func TestCompareJSONNumber(t *testing.T) {
+	tests := []struct {
+		s  string
+		r  string
+		c  int
+		ok bool
+	}{
+		{"1", "1", 0, true},
+		{"-1.5", "-3/2", 0, true},
+		{"1e3", "1000", 0, true},
+		{"1e-3", "0.001", 0, true},
+		{"1e100000000", "1e300", 1, true},
+		{"-1e100000000", "-1e300", -1, true},
+		{"1e-100000000", "0", 1, true},
+		{"abc", "0", 0, false},
+		{"1ex", "0", 0, false},
+	}
+	for _, tt := range tests {
+		c, ok := compareJSONNumber(tt.s, bigRat(tt.r))
+		if c != tt.c || ok != tt.ok {
+			t.Errorf("compare %s with %s: got %d %v; want %d %v", tt.s, tt.r, c, ok, tt.c, tt.ok)
+		}
+	}
+}
+
+func TestCompareBigFloat(t *testing.T) {
+	tests := []struct {
+		s string
+		r string
+		c int
+	}{
+		{"0", "0", 0},
+		{"0", "-1", 1},
+		{"-0.5", "1/3", -1},
+		{"0.1", "1/10", 1},
+		{"10", "10", 0},
+		{"10.5", "10", 1},
+		{"1e100000000", "10", 1},
+		{"-1e100000000", "10", -1},
+		{"-1e100000000", "-10", -1},
+		{"1e-100000000", "1/1000", -1},
+		{"-1e-100000000", "-1/1000", 1},
+		{"1e-100000000", "0", 1},
+	}
+	for _, tt := range tests {
+		x, _, err := big.ParseFloat(tt.s, 10, 64, big.ToNearestEven)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cmp, err := bigComparator(tt.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c, ok := cmp(reflect.ValueOf(x).Elem())
+		if c != tt.c || !ok {
+			t.Errorf("compare %s with %s: got %d %v; want %d", tt.s, tt.r, c, ok, tt.c)
+		}
+	}
+}
+
+func TestBigNumberInvalidParam(t *testing.T) {
+	s := struct {
+		A *big.Int    `valid:"min=a"`
+		B json.Number `valid:"max=1/0"`
+		C big.Float   `valid:"len=1"`
+	}{}
+	err := Default().Check(&s)
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	errs := err.(Errors)
+	if len(errs) != 3 {
+		t.Fatalf("unexpected errors length: %+v; want: %d", errs, 3)
+	}
+	if errs[0].(*TagError).Err.Error() != `invalid number "a"` {
+		t.Fatalf("unexpected error: %+v", errs[0])
+	}
+	if errs[2].(*TagError).Err != UnsupportedError("big.Float") {
+		t.Fatalf("unexpected error: %+v", errs[2])
+	}
+}
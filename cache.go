@@ -2,14 +2,17 @@ package validator
 
 import (
 	"reflect"
+	"regexp"
 	"sync"
 	"sync/atomic"
 )
 
 type field struct {
-	idx  int
-	name string
-	tags string
+	idx       int
+	name      string
+	rules     []rule
+	anonymous bool
+	omitEmpty bool // nested values are not validated when the field is empty
 }
 
 // fieldCache stores cached fields.
@@ -36,74 +39,26 @@ func (c *fieldCache) save(t reflect.Type, f []field) {
 	c.mu.Unlock()
 }
 
-// intCache is a cache of integer with string key.
-type intCache struct {
-	value atomic.Value // map[string]int64
+// regexpCache is a cache of compiled regular expressions with pattern key.
+type regexpCache struct {
+	value atomic.Value // map[string]*regexp.Regexp
 	mu    sync.Mutex
 }
 
-func (c *intCache) get(s string) (val int64, ok bool) {
-	m, _ := c.value.Load().(map[string]int64)
-	val, ok = m[s]
+func (c *regexpCache) get(s string) (re *regexp.Regexp, ok bool) {
+	m, _ := c.value.Load().(map[string]*regexp.Regexp)
+	re, ok = m[s]
 	return
 }
 
-func (c *intCache) save(s string, val int64) {
+func (c *regexpCache) save(s string, re *regexp.Regexp) {
 	c.mu.Lock()
-	m, _ := c.value.Load().(map[string]int64)
-	newM := make(map[string]int64, len(m)+1)
+	m, _ := c.value.Load().(map[string]*regexp.Regexp)
+	newM := make(map[string]*regexp.Regexp, len(m)+1)
 	for k, v := range m {
 		newM[k] = v
 	}
-	newM[s] = val
-	c.value.Store(newM)
-	c.mu.Unlock()
-}
-
-// uintCache is a cache of unsigned integer with string key.
-type uintCache struct {
-	value atomic.Value // map[string]uint
-	mu    sync.Mutex
-}
-
-func (c *uintCache) get(s string) (val uint64, ok bool) {
-	m, _ := c.value.Load().(map[string]uint64)
-	val, ok = m[s]
-	return
-}
-
-func (c *uintCache) save(s string, val uint64) {
-	c.mu.Lock()
-	m, _ := c.value.Load().(map[string]uint64)
-	newM := make(map[string]uint64, len(m)+1)
-	for k, v := range m {
-		newM[k] = v
-	}
-	newM[s] = val
-	c.value.Store(newM)
-	c.mu.Unlock()
-}
-
-// floatCache is a cache of float with string key.
-type floatCache struct {
-	value atomic.Value // map[string]float64
-	mu    sync.Mutex
-}
-
-func (c *floatCache) get(s string) (val float64, ok bool) {
-	m, _ := c.value.Load().(map[string]float64)
-	val, ok = m[s]
-	return
-}
-
-func (c *floatCache) save(s string, val float64) {
-	c.mu.Lock()
-	m, _ := c.value.Load().(map[string]float64)
-	newM := make(map[string]float64, len(m)+1)
-	for k, v := range m {
-		newM[k] = v
-	}
-	newM[s] = val
+	newM[s] = re
 	c.value.Store(newM)
 	c.mu.Unlock()
 }
@@ -0,0 +1,70 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Check verifies validation tags of the given types without validating
+// any value. Each type can be given as a value, a pointer or a reflect.Type.
+// Struct fields, slice, array and map elements are checked recursively.
+// It returns Errors containing a *TagError for every unknown function,
+// malformed parameter, usually reported as a *ParamError, or function
+// which does not support the field type.
+// The compiled rules are cached for subsequent validations.
+func (a *Validator) Check(types ...interface{}) error {
+	c := checker{
+		validator: a,
+		visited:   make(map[reflect.Type]bool),
+	}
+	for _, t := range types {
+		rt, ok := t.(reflect.Type)
+		if !ok {
+			rt = reflect.TypeOf(t)
+		}
+		if rt != nil {
+			c.checkType(rt)
+		}
+	}
+	if len(c.errors) == 0 {
+		return nil
+	}
+	return Errors(c.errors)
+}
+
+// MustCheck checks the given types like Check does and panics if there
+// is any invalid tag. It is intended to be used during initialization.
+func (a *Validator) MustCheck(types ...interface{}) {
+	if err := a.Check(types...); err != nil {
+		panic(fmt.Sprintf("validator: invalid tags:\n%v", err))
+	}
+}
+
+type checker struct {
+	validator *Validator
+	visited   map[reflect.Type]bool
+
+	errors []error
+}
+
+func (c *checker) checkType(rt reflect.Type) {
+	if c.visited[rt] {
+		return
+	}
+	c.visited[rt] = true
+	switch rt.Kind() {
+	case reflect.Struct:
+		c.checkStruct(rt)
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		c.checkType(rt.Elem())
+	}
+}
+
+func (c *checker) checkStruct(rt reflect.Type) {
+	fields := c.validator.getFields(rt)
+	for i := range fields {
+		ft := &fields[i]
+		c.errors = ruleErrors(c.errors, ft.rules)
+		c.checkType(rt.Field(ft.idx).Type)
+	}
+}
@@ -0,0 +1,87 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	type s1 struct {
+		A int    `valid:"min=abc"`
+		B string `valid:"mni=3"`
+		C bool   `valid:"max=1"`
+		D string `valid:"notempty,max=10"`
+	}
+	type s2 struct {
+		E []*s1
+		F map[string]s1
+		G *s2
+		H interface{} `valid:"notempty"`
+	}
+	v := Default()
+	err := v.Check(s2{}, (*s1)(nil))
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	errs := err.(Errors)
+	if len(errs) != 3 {
+		t.Fatalf("unexpected errors length: %+v; want: %d", errs, 3)
+	}
+	e := errs[0].(*TagError)
+	if e.Type != reflect.TypeOf(s1{}) || e.Field != "A" || e.Rule != "min" || e.Param != "abc" {
+		t.Fatalf("unexpected error: %#v", e)
+	}
+	if errs[1].(*TagError).Err != UnsupportedError("mni") {
+		t.Fatalf("unexpected error: %#v", errs[1])
+	}
+	if errs[2].Error() != `rule "max=1" of validator.s1.C: validator: unsupported: bool` {
+		t.Fatalf("unexpected error: %v", errs[2])
+	}
+	err = v.Check(reflect.TypeOf(s2{}).Field(0).Type)
+	if err == nil || len(err.(Errors)) != 3 {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCheckValid(t *testing.T) {
+	type s1 struct {
+		A int      `valid:"min=1"`
+		B []string `valid:"notempty,max=10"`
+	}
+	v := Default()
+	err := v.Check(&s1{}, []s1{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	v.MustCheck(s1{})
+}
+
+func TestMustCheck(t *testing.T) {
+	type s1 struct {
+		A int `valid:"min=abc"`
+	}
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("panic expected")
+		}
+	}()
+	Default().MustCheck(s1{})
+}
+
+func TestInvalidTagValidate(t *testing.T) {
+	s := struct {
+		A int `valid:"min=abc"`
+		B int `valid:"min=1"`
+	}{}
+	err := Default().Validate(&s)
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	errs := err.(Errors)
+	if len(errs) != 2 {
+		t.Fatalf("unexpected errors length: %+v; want: %d", errs, 2)
+	}
+	if _, ok := errs[0].(*TagError); !ok {
+		t.Fatalf("unexpected error: %#v", errs[0])
+	}
+}
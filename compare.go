@@ -0,0 +1,161 @@
+package validator
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// number is a numeric parameter. Only one of its fields is used depending
+// on its kind, so integers are compared exactly with values of any kind.
+type number struct {
+	kind reflect.Kind // reflect.Int64, reflect.Uint64 or reflect.Float64
+	i    int64
+	u    uint64 // for integers greater than math.MaxInt64
+	f    float64
+}
+
+// parseNumber parses s as an integer, in base 0 like parseInt, or as a float.
+func parseNumber(s string) (number, error) {
+	if i, err := strconv.ParseInt(s, 0, 64); err == nil {
+		return number{kind: reflect.Int64, i: i}, nil
+	}
+	if u, err := strconv.ParseUint(s, 0, 64); err == nil {
+		return number{kind: reflect.Uint64, u: u}, nil
+	}
+	f, err := parseFloat(s)
+	if err != nil {
+		return number{}, err
+	}
+	return number{kind: reflect.Float64, f: f}, nil
+}
+
+// float32 returns n rounded to float32 if it is a float so it can be
+// compared with float32 values.
+func (n number) float32() number {
+	if n.kind == reflect.Float64 {
+		n.f = float64(float32(n.f))
+	}
+	return n
+}
+
+// compareInt compares integer x with n.
+func (n number) compareInt(x int64) int {
+	switch n.kind {
+	case reflect.Int64:
+		return compareInt(x, n.i)
+	case reflect.Uint64:
+		return -1
+	}
+	return -compareFloatInt(n.f, x)
+}
+
+// compareUint compares unsigned integer x with n.
+func (n number) compareUint(x uint64) int {
+	switch n.kind {
+	case reflect.Int64:
+		if n.i < 0 {
+			return 1
+		}
+		return compareUint(x, uint64(n.i))
+	case reflect.Uint64:
+		return compareUint(x, n.u)
+	}
+	return -compareFloatUint(n.f, x)
+}
+
+// compareFloat compares float x with n.
+func (n number) compareFloat(x float64) int {
+	switch n.kind {
+	case reflect.Int64:
+		return compareFloatInt(x, n.i)
+	case reflect.Uint64:
+		return compareFloatUint(x, n.u)
+	}
+	return compareFloat(x, n.f)
+}
+
+// compareNumbers compares two numeric values of any kinds exactly.
+func compareNumbers(v1, v2 reflect.Value) int {
+	switch v1.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch v2.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return compareInt(v1.Int(), v2.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if v1.Int() < 0 {
+				return -1
+			}
+			return compareUint(uint64(v1.Int()), v2.Uint())
+		}
+		return -compareFloatInt(v2.Float(), v1.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		switch v2.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return -compareNumbers(v2, v1)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return compareUint(v1.Uint(), v2.Uint())
+		}
+		return -compareFloatUint(v2.Float(), v1.Uint())
+	}
+	switch v2.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compareFloatInt(v1.Float(), v2.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return compareFloatUint(v1.Float(), v2.Uint())
+	}
+	return compareFloat(v1.Float(), v2.Float())
+}
+
+func compareInt(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// compareFloatInt compares float a with integer b exactly. When a is not
+// equal to b rounded to float64, no other float lies between them, so
+// they compare the same. Otherwise a is an integer.
+func compareFloatInt(a float64, b int64) int {
+	if c := float64(b); a != c {
+		return compareFloat(a, c)
+	}
+	if a >= 1<<63 {
+		return 1
+	}
+	return compareInt(int64(a), b)
+}
+
+// compareFloatUint is like compareFloatInt for unsigned integer b.
+func compareFloatUint(a float64, b uint64) int {
+	if c := float64(b); a != c {
+		return compareFloat(a, c)
+	}
+	if a >= 1<<64 {
+		return 1
+	}
+	return compareUint(uint64(a), b)
+}
@@ -0,0 +1,74 @@
+package validator
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestCompareFloatInt(t *testing.T) {
+	tests := []struct {
+		a float64
+		b int64
+		c int
+	}{
+		{0, 0, 0},
+		{-1.5, -1, -1},
+		{1 << 53, 1<<53 + 1, -1},
+		{1<<53 + 2, 1<<53 + 1, 1},
+		{1 << 63, math.MaxInt64, 1},
+		{-1 << 63, math.MinInt64, 0},
+		{math.Inf(-1), math.MinInt64, -1},
+	}
+	for _, tt := range tests {
+		if c := compareFloatInt(tt.a, tt.b); c != tt.c {
+			t.Errorf("compare %v with %v: got %d; want %d", tt.a, tt.b, c, tt.c)
+		}
+	}
+	if c := compareFloatUint(1<<64, math.MaxUint64); c != 1 {
+		t.Errorf("unexpected comparison: %d", c)
+	}
+	if c := compareFloatUint(1<<63, 1<<63+1); c != -1 {
+		t.Errorf("unexpected comparison: %d", c)
+	}
+}
+
+func TestCompareNumbers(t *testing.T) {
+	tests := []struct {
+		a, b interface{}
+		c    int
+	}{
+		{int8(-1), uint64(math.MaxUint64), -1},
+		{uint(1), int(-1), 1},
+		{int64(1<<53 + 1), float64(1 << 53), 1},
+		{uint64(math.MaxUint64), float64(1 << 64), -1},
+		{float32(0.5), uint8(1), -1},
+		{float64(2), float32(2), 0},
+	}
+	for _, tt := range tests {
+		if c := compareNumbers(reflect.ValueOf(tt.a), reflect.ValueOf(tt.b)); c != tt.c {
+			t.Errorf("compare %v with %v: got %d; want %d", tt.a, tt.b, c, tt.c)
+		}
+	}
+}
+
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		s string
+		n number
+	}{
+		{"-0x10", number{kind: reflect.Int64, i: -16}},
+		{"18446744073709551615", number{kind: reflect.Uint64, u: math.MaxUint64}},
+		{"1e3", number{kind: reflect.Float64, f: 1000}},
+	}
+	for _, tt := range tests {
+		n, err := parseNumber(tt.s)
+		if err != nil || n != tt.n {
+			t.Errorf("parse %s: got %+v %v; want %+v", tt.s, n, err, tt.n)
+		}
+	}
+	_, err := parseNumber("1..2")
+	if e, ok := err.(*ParamError); !ok || e.Error() != `invalid number "1..2": invalid syntax` {
+		t.Errorf("unexpected error: %#v", err)
+	}
+}
@@ -0,0 +1,206 @@
+package validator
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+func requiredIf(a *Validator, st reflect.Type, f reflect.StructField, param string) (Func, error) {
+	cond, err := a.fieldValueCondition(st, param)
+	if err != nil {
+		return nil, err
+	}
+	return conditional(f, cond.match, false, "must not be empty when "+cond.String()), nil
+}
+
+func requiredUnless(a *Validator, st reflect.Type, f reflect.StructField, param string) (Func, error) {
+	cond, err := a.fieldValueCondition(st, param)
+	if err != nil {
+		return nil, err
+	}
+	match := func(v reflect.Value) bool {
+		return !cond.match(v)
+	}
+	return conditional(f, match, false, "must not be empty unless "+cond.String()), nil
+}
+
+func requiredWith(a *Validator, st reflect.Type, f reflect.StructField, param string) (Func, error) {
+	refs, err := a.lookupFields(st, param)
+	if err != nil {
+		return nil, err
+	}
+	// Required if any of the fields is present
+	match := func(v reflect.Value) bool {
+		for _, r := range refs {
+			fv, ok := r.value(v)
+			if ok && !isEmpty(fv) {
+				return true
+			}
+		}
+		return false
+	}
+	return conditional(f, match, false, "must not be empty when "+fieldNames(refs)+" is set"), nil
+}
+
+func requiredWithout(a *Validator, st reflect.Type, f reflect.StructField, param string) (Func, error) {
+	refs, err := a.lookupFields(st, param)
+	if err != nil {
+		return nil, err
+	}
+	// Required if any of the fields is missing
+	match := func(v reflect.Value) bool {
+		for _, r := range refs {
+			fv, ok := r.value(v)
+			if !ok || isEmpty(fv) {
+				return true
+			}
+		}
+		return false
+	}
+	return conditional(f, match, false, "must not be empty when "+fieldNames(refs)+" is not set"), nil
+}
+
+func excludedIf(a *Validator, st reflect.Type, f reflect.StructField, param string) (Func, error) {
+	cond, err := a.fieldValueCondition(st, param)
+	if err != nil {
+		return nil, err
+	}
+	return conditional(f, cond.match, true, "must be empty when "+cond.String()), nil
+}
+
+// conditional returns a function validating field f in a struct. When match
+// returns true for the struct, the field must not be empty, or must be empty
+// if excluded is true.
+func conditional(f reflect.StructField, match func(reflect.Value) bool, excluded bool, msg string) Func {
+	idx := f.Index[0]
+	return func(v reflect.Value, name, param string) error {
+		fv := v.Field(idx)
+		if isEmpty(fv) != excluded && match(v) {
+			return newFieldError(fv, name, param, "%s", msg)
+		}
+		return nil
+	}
+}
+
+// fieldCondition matches fields with their expected values.
+type fieldCondition struct {
+	refs   []*fieldRef
+	values []string
+	equals []func(reflect.Value) bool
+}
+
+// fieldValueCondition parses param as pairs of field name and value
+// separated by spaces, e.g. "Method card Country AU".
+func (a *Validator) fieldValueCondition(st reflect.Type, param string) (*fieldCondition, error) {
+	args := strings.Fields(param)
+	if len(args) == 0 || len(args)%2 != 0 {
+		return nil, &ParamError{Param: param, Kind: "field and value pairs"}
+	}
+	c := &fieldCondition{}
+	for i := 0; i < len(args); i += 2 {
+		ref, err := a.lookupField(st, args[i])
+		if err != nil {
+			return nil, err
+		}
+		eq, err := valueEqual(indirectType(ref.typ), args[i+1])
+		if err != nil {
+			return nil, err
+		}
+		c.refs = append(c.refs, ref)
+		c.values = append(c.values, args[i+1])
+		c.equals = append(c.equals, eq)
+	}
+	return c, nil
+}
+
+// match returns true if all fields in struct v have their expected values.
+func (c *fieldCondition) match(v reflect.Value) bool {
+	for i, r := range c.refs {
+		fv, ok := r.value(v)
+		if !ok || !c.equals[i](fv) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *fieldCondition) String() string {
+	parts := make([]string, len(c.refs))
+	for i, r := range c.refs {
+		parts[i] = r.name + " is " + c.values[i]
+	}
+	return strings.Join(parts, " and ")
+}
+
+// lookupFields resolves field paths separated by spaces in param.
+func (a *Validator) lookupFields(st reflect.Type, param string) ([]*fieldRef, error) {
+	paths := strings.Fields(param)
+	if len(paths) == 0 {
+		return nil, &ParamError{Param: param, Kind: "fields"}
+	}
+	refs := make([]*fieldRef, len(paths))
+	for i, path := range paths {
+		ref, err := a.lookupField(st, path)
+		if err != nil {
+			return nil, err
+		}
+		refs[i] = ref
+	}
+	return refs, nil
+}
+
+func fieldNames(refs []*fieldRef) string {
+	names := make([]string, len(refs))
+	for i, r := range refs {
+		names[i] = r.name
+	}
+	return strings.Join(names, " or ")
+}
+
+// valueEqual returns a function which checks if values of type t equal to s.
+func valueEqual(t reflect.Type, s string) (func(reflect.Value) bool, error) {
+	switch t.Kind() {
+	case reflect.String:
+		return func(v reflect.Value) bool {
+			return v.String() == s
+		}, nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, &ParamError{Param: s, Kind: "bool"}
+		}
+		return func(v reflect.Value) bool {
+			return v.Bool() == b
+		}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := parseInt(s)
+		if err != nil {
+			return nil, err
+		}
+		return func(v reflect.Value) bool {
+			return v.Int() == n
+		}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := parseUint(s)
+		if err != nil {
+			return nil, err
+		}
+		return func(v reflect.Value) bool {
+			return v.Uint() == n
+		}, nil
+	case reflect.Float32, reflect.Float64:
+		n, err := parseFloat(s)
+		if err != nil {
+			return nil, err
+		}
+		if t.Kind() == reflect.Float32 {
+			// Compare with the value as precise as float32 fields.
+			n = float64(float32(n))
+		}
+		return func(v reflect.Value) bool {
+			return v.Float() == n
+		}, nil
+	}
+	return nil, errUnsupported
+}
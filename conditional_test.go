@@ -0,0 +1,98 @@
+package validator
+
+import "testing"
+
+func TestRequiredIf(t *testing.T) {
+	type s1 struct {
+		Method     string
+		Country    string
+		Express    bool
+		Count      int
+		CardNumber string  `valid:"required_if=Method card"`
+		Postcode   *string `valid:"required_if=Method post Country AU"`
+		Phone      string  `valid:"required_if=Express true"`
+		Items      []int   `valid:"required_unless=Count 0"`
+		Cash       float64 `valid:"excluded_if=Method card"`
+	}
+	v := Default()
+	err := v.Validate(&s1{Method: "cash", Country: "AU", Cash: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = v.Validate(&s1{Method: "post", Country: "AU", Express: true, Count: 1})
+	assertNOK(t, err,
+		"Postcode must not be empty when Method is post and Country is AU",
+		"Phone must not be empty when Express is true",
+		"Items must not be empty unless Count is 0",
+	)
+	err = v.Validate(&s1{Method: "card", Cash: 1.5})
+	assertNOK(t, err,
+		"CardNumber must not be empty when Method is card",
+		"Cash must be empty when Method is card",
+	)
+}
+
+func TestRequiredIfFloat32(t *testing.T) {
+	type s1 struct {
+		R float32
+		S *float32
+		A string `valid:"required_if=R 0.1"`
+		B string `valid:"required_if=S 0.1"`
+	}
+	s := float32(0.1)
+	err := Default().Validate(&s1{R: 0.1, S: &s})
+	assertNOK(t, err,
+		"A must not be empty when R is 0.1",
+		"B must not be empty when S is 0.1",
+	)
+}
+
+func TestRequiredWith(t *testing.T) {
+	type address struct {
+		Street string
+	}
+	type s1 struct {
+		Address *address
+		City    string
+		Country string `valid:"required_with=Address.Street City"`
+		Phone   string `valid:"required_without=Email"`
+		Email   string `valid:"required_without=Phone"`
+	}
+	v := Default()
+	err := v.Validate(&s1{Phone: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = v.Validate(&s1{Address: &address{"a"}})
+	assertNOK(t, err,
+		"Country must not be empty when Address.Street or City is set",
+		"Phone must not be empty when Email is not set",
+		"Email must not be empty when Phone is not set",
+	)
+	err = v.Validate(&s1{City: "c", Country: "AU", Email: "e"})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConditionalCheck(t *testing.T) {
+	type s1 struct {
+		A int `valid:"required_if=B"`
+		B int `valid:"required_if=A x"`
+		C int `valid:"required_with=D"`
+		D int `valid:"required_without"`
+		E int `valid:"required_if=F maybe"`
+		F bool
+	}
+	err := Default().Check(s1{})
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	errs := err.(Errors)
+	if len(errs) != 4 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if e, ok := errs[3].(*TagError).Err.(*ParamError); !ok || e.Error() != `invalid bool "maybe"` {
+		t.Fatalf("unexpected error: %+v", errs[3])
+	}
+}
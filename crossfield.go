@@ -0,0 +1,149 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+func eqField(a *Validator, st reflect.Type, f reflect.StructField, param string) (Func, error) {
+	return fieldComparison(a, st, f, param, "must be equal to", func(c int) bool {
+		return c == 0
+	})
+}
+
+func neField(a *Validator, st reflect.Type, f reflect.StructField, param string) (Func, error) {
+	return fieldComparison(a, st, f, param, "must not be equal to", func(c int) bool {
+		return c != 0
+	})
+}
+
+func gtField(a *Validator, st reflect.Type, f reflect.StructField, param string) (Func, error) {
+	return fieldComparison(a, st, f, param, "must be greater than", func(c int) bool {
+		return c > 0
+	})
+}
+
+func gteField(a *Validator, st reflect.Type, f reflect.StructField, param string) (Func, error) {
+	return fieldComparison(a, st, f, param, "must not be less than", func(c int) bool {
+		return c >= 0
+	})
+}
+
+func ltField(a *Validator, st reflect.Type, f reflect.StructField, param string) (Func, error) {
+	return fieldComparison(a, st, f, param, "must be less than", func(c int) bool {
+		return c < 0
+	})
+}
+
+func lteField(a *Validator, st reflect.Type, f reflect.StructField, param string) (Func, error) {
+	return fieldComparison(a, st, f, param, "must not be greater than", func(c int) bool {
+		return c <= 0
+	})
+}
+
+// fieldComparison returns a function which compares field f with the field
+// at path param in struct type st. Values are valid if ok returns true for
+// the comparison result. Nil pointers are not validated.
+func fieldComparison(a *Validator, st reflect.Type, f reflect.StructField, param, msg string, ok func(int) bool) (Func, error) {
+	other, err := a.lookupField(st, param)
+	if err != nil {
+		return nil, err
+	}
+	if !comparableTypes(indirectType(f.Type), indirectType(other.typ)) {
+		return nil, errUnsupported
+	}
+	idx := f.Index[0]
+	return func(v reflect.Value, name, param string) error {
+		fv, fok := indirect(v.Field(idx))
+		ov, ook := other.value(v)
+		if !fok || !ook {
+			return errSkip
+		}
+		if !ok(compareValues(fv, ov)) {
+			// Name the other field with the same parent path.
+			prefix := name[:strings.LastIndex(name, ".")+1]
+			return newFieldError(fv, name, param, "%s %s%s", msg, prefix, other.name)
+		}
+		return nil
+	}, nil
+}
+
+// fieldRef is a reference to a field, possibly nested, of a struct.
+type fieldRef struct {
+	index []int
+	typ   reflect.Type
+	name  string // display name
+}
+
+// lookupField resolves field path in struct type st. Nested fields
+// are separated by a dot, e.g. "Range.Start".
+func (a *Validator) lookupField(st reflect.Type, path string) (*fieldRef, error) {
+	ref := &fieldRef{typ: st}
+	var names []string
+	for _, name := range strings.Split(path, ".") {
+		t := indirectType(ref.typ)
+		if t.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("unknown field %q", path)
+		}
+		// Unexported fields are unknown as their values cannot be used.
+		sf, ok := t.FieldByName(name)
+		if !ok || sf.PkgPath != "" {
+			return nil, fmt.Errorf("unknown field %q", path)
+		}
+		ref.index = append(ref.index, sf.Index...)
+		ref.typ = sf.Type
+		names = append(names, a.fieldName(sf))
+	}
+	ref.name = strings.Join(names, ".")
+	return ref, nil
+}
+
+// value returns the referenced field in struct v or false if the field
+// is not reachable because of a nil pointer.
+func (r *fieldRef) value(v reflect.Value) (reflect.Value, bool) {
+	for _, i := range r.index {
+		var ok bool
+		v, ok = indirect(v)
+		if !ok {
+			return v, false
+		}
+		v = v.Field(i)
+	}
+	return indirect(v)
+}
+
+// comparableTypes returns true if values of types t1 and t2 can be compared
+// with compareValues.
+func comparableTypes(t1, t2 reflect.Type) bool {
+	if t1 == timeType || t2 == timeType {
+		return t1 == t2
+	}
+	switch t1.Kind() {
+	case reflect.String:
+		return t2.Kind() == reflect.String
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		switch t2.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+			reflect.Float32, reflect.Float64:
+			return true
+		}
+	}
+	return false
+}
+
+// compareValues returns -1, 0 or 1 if v1 is less than, equal to or
+// greater than v2. Types of v1 and v2 must be comparable.
+func compareValues(v1, v2 reflect.Value) int {
+	if v1.Type() == timeType {
+		return compareTime(v1.Interface().(time.Time), v2.Interface().(time.Time))
+	}
+	if v1.Kind() == reflect.String {
+		return strings.Compare(v1.String(), v2.String())
+	}
+	return compareNumbers(v1, v2)
+}
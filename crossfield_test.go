@@ -0,0 +1,94 @@
+package validator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCrossField(t *testing.T) {
+	type period struct {
+		Start time.Time
+		End   time.Time `valid:"gtfield=Start"`
+	}
+	type s1 struct {
+		Password        string `valid:"notempty"`
+		PasswordConfirm string `valid:"eqfield=Password"`
+		Username        string `valid:"nefield=Password"`
+		Min             int
+		Max             uint     `valid:"gtefield=Min"`
+		Ratio           *float64 `valid:"ltfield=Max"`
+		Limit           int8     `valid:"ltefield=Period.End"`
+		Period          *period
+		Periods         []period
+	}
+	now := time.Now()
+	ratio := 10.0
+	s := s1{
+		Password:        "secret",
+		PasswordConfirm: "secrets",
+		Username:        "secret",
+		Min:             -1,
+		Max:             0,
+		Ratio:           &ratio,
+		Periods: []period{
+			period{Start: now, End: now.Add(time.Second)},
+			period{Start: now, End: now},
+		},
+	}
+	v := Default()
+	err := v.Validate(&s)
+	assertNOK(t, err,
+		"PasswordConfirm must be equal to Password",
+		"Username must not be equal to Password",
+		"Ratio must be less than Max",
+		// Limit cannot be compared with a time.Time.
+		"validator: unsupported: Limit",
+		"Periods[1].End must be greater than Periods[1].Start",
+	)
+}
+
+func TestCrossFieldNested(t *testing.T) {
+	type account struct {
+		Email string
+	}
+	type s1 struct {
+		Account *account
+		Email   string `json:"email" valid:"eqfield=Account.Email"`
+	}
+	v := New(DefaultOption(), WithNameTag("json"))
+	err := v.Validate(&s1{Email: "a@b.c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = v.Validate(&s1{Account: &account{"b@c.d"}, Email: "a@b.c"})
+	assertNOK(t, err, "email must be equal to Account.Email")
+	e := err.(Errors)[0].(*FieldError)
+	if e.Rule != "eqfield" || e.Param != "Account.Email" || e.Value != "a@b.c" {
+		t.Fatalf("unexpected error: %#v", e)
+	}
+}
+
+func TestCrossFieldCheck(t *testing.T) {
+	type s1 struct {
+		A int `valid:"eqfield=B"`
+		B string
+		C int `valid:"ltfield=D.E"`
+		D string
+		E time.Time `valid:"eqfield=f"`
+		f time.Time
+	}
+	err := Default().Check(s1{})
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	errs := err.(Errors)
+	if len(errs) != 3 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if errs[1].Error() != `rule "ltfield=D.E" of validator.s1.C: unknown field "D.E"` {
+		t.Fatalf("unexpected error: %v", errs[1])
+	}
+	if errs[2].Error() != `rule "eqfield=f" of validator.s1.E: unknown field "f"` {
+		t.Fatalf("unexpected error: %v", errs[2])
+	}
+}
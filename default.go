@@ -1,13 +1,138 @@
 package validator
 
 // DefaultOption returns an Option which sets validator to use
-// tag name 'valid' and support function 'notempty', 'min', 'max'.
+// tag name 'valid' and support function 'notempty', 'min', 'max', 'len',
+// 'between', 'gt', 'gte', 'lt', 'lte', 'minrunes', 'maxrunes', 'eqfield',
+// 'nefield', 'gtfield', 'gtefield', 'ltfield', 'ltefield',
+// 'required_if', 'required_unless', 'required_with', 'required_without',
+// 'excluded_if', 'regexp', 'oneof', 'oneofci', 'email', 'url', 'uri',
+// 'hostname', 'fqdn', 'ip', 'ipv4', 'ipv6', 'cidr', 'mac', 'port', 'hostport',
+// 'uuid', 'ulid', 'hex', 'base64', 'base64url', 'base32', 'past', 'future',
+// 'datetime', 'rfc3339', 'date'.
+//
+// Functions 'min', 'max', 'len', 'between', 'gt', 'gte', 'lt' and 'lte'
+// compare numbers with their parameter, or lengths of strings, slices,
+// arrays and maps. 'gte' and 'lte' are the same as 'min' and 'max', while
+// 'gt' and 'lt' exclude the parameter, e.g. 'gt=0' for positive numbers.
+// Function 'between' takes inclusive lower and upper bounds separated by
+// a space, e.g. 'between=1 10'. Function 'len' only supports lengths.
+// Integers and floats are compared exactly regardless of their kinds, so
+// 'min=-1' always passes for unsigned integers.
+//
+// Numbers of types *big.Int, *big.Rat, *big.Float and json.Number are
+// compared exactly with integer, decimal or fraction parameters, e.g.
+// 'max=0.1' or 'gt=1/3'.
+//
+// Bound functions also support time.Duration with parameters such as '30s',
+// or in nanoseconds without a unit, and time.Time with parameters in RFC 3339
+// format or relative to the current time, e.g. 'min=2006-01-02T15:04:05Z' or
+// 'max=now+24h'. Functions 'past' and 'future' compare time.Time with the
+// current time given by WithClock.
+//
+// Function 'datetime' requires a string or []byte to be parsed by time.Parse
+// with the layout given as its parameter, e.g. 'datetime=2006-01-02 15:04'.
+// Functions 'rfc3339' and 'date' use layouts time.RFC3339 and '2006-01-02'.
+// Bound functions compare strings as time when their parameter is in one of
+// those layouts or relative to the current time, e.g. 'date,min=2000-01-01'.
+//
+// Lengths of strings are in bytes. Functions 'minrunes' and 'maxrunes'
+// count runes instead, so "Zoë" has length 4 but 3 runes. They support
+// strings and []byte.
+//
+// Function 'regexp' requires a string or []byte to match the regular
+// expression given as its parameter, e.g. regexp='^[a-z]{1,3}$'.
+// Patterns are compiled once and shared by all fields of the validator.
+//
+// Function 'oneof' requires a string or number to equal to one of the values
+// separated by spaces, e.g. 'oneof=active suspended'. Function 'oneofci' is
+// like 'oneof' but compares strings case-insensitively.
+//
+// Format functions 'email', 'url', 'uri', 'hostname' and 'fqdn' validate
+// strings. Function 'email' accepts an addr-spec of RFC 5322, and also
+// Unicode domain names with 'email=idn'. Function 'url' requires an absolute
+// URL with a host and optionally one of the schemes separated by spaces,
+// e.g. 'url=http https', while 'uri' only requires a scheme. Function
+// 'hostname' accepts a host name of RFC 1123 and 'fqdn' requires at least
+// two labels with a non-numeric top-level domain.
+//
+// Network functions 'ip', 'ipv4' and 'ipv6' validate strings, net.IP and
+// netip.Addr. Function 'cidr' validates strings and netip.Prefix, 'mac'
+// validates strings and net.HardwareAddr, 'port' validates strings and
+// integers, and 'hostport' validates strings, e.g. 'example.com:80' or
+// ':8080', and netip.AddrPort.
+//
+// Identifier functions 'uuid', 'ulid', 'hex', 'base64', 'base64url' and
+// 'base32' validate strings and []byte. Function 'uuid' accepts any version
+// unless one is given, e.g. 'uuid=4'. Function 'base64url' accepts encodings
+// with or without padding, while 'base64' and 'base32' require padding.
+//
+// Cross-field functions compare a field with another field of the same
+// struct given by name, or by path for nested fields, e.g. 'gtfield=Start'
+// or 'eqfield=Account.Email'. They support numbers, strings and time.Time.
+//
+// Conditional functions 'required_if', 'required_unless', 'required_with',
+// 'required_without' and 'excluded_if' require a field to be set, or not
+// set, depending on other fields of the same struct:
+//
+//	required_if=Method card       required when Method is "card"
+//	required_unless=Method cash   required unless Method is "cash"
+//	required_with=Street City     required when Street or City is set
+//	required_without=Phone Email  required when Phone or Email is not set
+//	excluded_if=Method cash       must be empty when Method is "cash"
+//
+// Multiple field and value pairs in 'required_if', 'required_unless' and
+// 'excluded_if' must all match.
 func DefaultOption() Option {
 	return func(v *Validator) {
 		v.tagName = defaultTagName
 		v.register("notempty", notEmpty)
-		v.register("min", min)
-		v.register("max", max)
+		v.register("min", v.min)
+		v.register("max", v.max)
+		v.register("len", v.length)
+		v.register("between", v.between)
+		v.register("gt", v.gt)
+		v.register("gte", v.min)
+		v.register("lt", v.lt)
+		v.register("lte", v.max)
+		v.register("minrunes", minRunes)
+		v.register("maxrunes", maxRunes)
+		v.registerStruct("eqfield", eqField)
+		v.registerStruct("nefield", neField)
+		v.registerStruct("gtfield", gtField)
+		v.registerStruct("gtefield", gteField)
+		v.registerStruct("ltfield", ltField)
+		v.registerStruct("ltefield", lteField)
+		v.registerStruct("required_if", requiredIf)
+		v.registerStruct("required_unless", requiredUnless)
+		v.registerStruct("required_with", requiredWith)
+		v.registerStruct("required_without", requiredWithout)
+		v.registerStruct("excluded_if", excludedIf)
+		v.register("regexp", v.regexpMatch)
+		v.register("oneof", oneOf)
+		v.register("oneofci", oneOfCI)
+		v.register("email", emailFormat)
+		v.register("url", urlFormat)
+		v.register("uri", uriFormat)
+		v.register("hostname", hostnameFormat)
+		v.register("fqdn", fqdnFormat)
+		v.register("ip", ipFormat)
+		v.register("ipv4", ipv4Format)
+		v.register("ipv6", ipv6Format)
+		v.register("cidr", cidrFormat)
+		v.register("mac", macFormat)
+		v.register("port", portFormat)
+		v.register("hostport", hostPortFormat)
+		v.register("uuid", uuidFormat)
+		v.register("ulid", ulidFormat)
+		v.register("hex", hexFormat)
+		v.register("base64", base64Format)
+		v.register("base64url", base64URLFormat)
+		v.register("base32", base32Format)
+		v.register("past", v.past)
+		v.register("future", v.future)
+		v.register("datetime", datetime)
+		v.register("rfc3339", rfc3339)
+		v.register("date", date)
 	}
 }
 
@@ -16,3 +141,58 @@ func DefaultOption() Option {
 func Default() *Validator {
 	return New(DefaultOption())
 }
+
+// negatedMessages contains error message formats of negated built-in
+// functions. The function parameter is given to the format if it has
+// a verb.
+var negatedMessages = map[string]string{
+	"notempty":  "must be empty",
+	"minrunes":  "must have length less than %s runes",
+	"maxrunes":  "must have length greater than %s runes",
+	"eqfield":   "must not be equal to %s",
+	"nefield":   "must be equal to %s",
+	"gtfield":   "must not be greater than %s",
+	"gtefield":  "must be less than %s",
+	"ltfield":   "must not be less than %s",
+	"ltefield":  "must be greater than %s",
+	"regexp":    "must not match %s",
+	"oneof":     "must not be one of [%s]",
+	"oneofci":   "must not be one of [%s]",
+	"email":     "must not be an email address",
+	"url":       "must not be a URL",
+	"uri":       "must not be a URI",
+	"hostname":  "must not be a hostname",
+	"fqdn":      "must not be a fully qualified domain name",
+	"ip":        "must not be an IP address",
+	"ipv4":      "must not be an IPv4 address",
+	"ipv6":      "must not be an IPv6 address",
+	"cidr":      "must not be a CIDR notation",
+	"mac":       "must not be a MAC address",
+	"port":      "must not be a port number",
+	"hostport":  "must not be a host and port",
+	"uuid":      "must not be a UUID",
+	"ulid":      "must not be a ULID",
+	"hex":       "must not be a hexadecimal string",
+	"base64":    "must not be a base64 string",
+	"base64url": "must not be a base64url string",
+	"base32":    "must not be a base32 string",
+	"past":      "must not be in the past",
+	"future":    "must not be in the future",
+	"datetime":  "must not be in format %s",
+	"rfc3339":   "must not be an RFC 3339 date and time",
+	"date":      "must not be a date",
+}
+
+// negatedBounds contains bounds whose messages are used by negated bound
+// functions according to the class of values compared, e.g. '!min=3'
+// fails like 'lt=3'.
+var negatedBounds = map[string]*bound{
+	"min":     &ltBound,
+	"max":     &gtBound,
+	"len":     &notLenBound,
+	"between": &notBetweenBound,
+	"gt":      &maxBound,
+	"gte":     &ltBound,
+	"lt":      &minBound,
+	"lte":     &gtBound,
+}
@@ -53,6 +53,6 @@ func ExampleValidator() {
 	// Age must not be less than 13 (was 0),
 	// Addresses must have length not greater than 2 (was 3),
 	// Either address Line1 or Line2 must be set,
-	// PostCode must not be less than 1 (was -1),
-	// Country must have length not greater than 2 (was 3)
+	// Addresses[1].PostCode must not be less than 1 (was -1),
+	// Addresses[2].Country must have length not greater than 2 (was 3)
 }
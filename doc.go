@@ -0,0 +1,34 @@
+/*
+Package validator provides validation for structs and fields.
+
+Rules of a struct field are given in its 'valid' tag and separated by
+comma. Each rule is a function name optionally followed by '=' and
+a parameter:
+
+	Name string `valid:"notempty,max=32"`
+
+Besides registered functions, a tag can contain:
+
+	omitempty   skip the rules after it, and fields of nested structs, when
+	            the field is empty, i.e. its zero value, a nil pointer or an
+	            empty string, slice or map
+	dive        apply the rules after it to each element of a slice, array
+	            or map, e.g. 'max=10,dive,notempty,max=32'
+	keys        start the rules for map keys right after dive, e.g.
+	            'dive,keys,notempty,endkeys,min=0', whose errors are
+	            reported at paths such as 'M[k](key)'
+	endkeys     end the rules for map keys
+	a|b         pass if any of the alternative rules passes
+	!a          pass if the rule does not pass, e.g. '!eqfield=Username'
+	not(a|b)    pass if none of the rules passes
+
+Parameters containing ',' or '|' can be quoted with single quotes, e.g.
+regexp='^\d{1,3}$', or escaped with a backslash, e.g. oneof=a\,b.
+Inside quotes, only a single quote and a backslash need to be escaped.
+A single quote only starts quoting at the beginning of a parameter, so
+parameters such as oneof=don't are kept as is.
+
+Built-in functions skip values they do not validate, such as nil pointers,
+and so do negated rules, e.g. '!email' passes for a nil *string.
+*/
+package validator
@@ -0,0 +1,251 @@
+package validator
+
+import (
+	"net"
+	"net/url"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// idnParam allows internationalized domain names in email addresses.
+	idnParam = "idn"
+
+	maxHostnameLength  = 253
+	maxLabelLength     = 63
+	maxLocalPartLength = 64
+	maxEmailLength     = 254
+)
+
+func emailFormat(t reflect.Type, param string) (Func, error) {
+	var idn bool
+	switch param {
+	case "":
+	case idnParam:
+		idn = true
+	default:
+		return nil, &ParamError{Param: param, Kind: "option"}
+	}
+	return stringFormat(t, func(s string) bool {
+		return isEmail(s, idn)
+	}, "must be a valid email address")
+}
+
+// urlFormat returns a function validating absolute URLs with a host. The
+// schemes allowed can be given in param separated by spaces, e.g.
+// "http https".
+func urlFormat(t reflect.Type, param string) (Func, error) {
+	schemes := strings.Fields(strings.ToLower(param))
+	msg := "must be a valid URL"
+	if len(schemes) > 0 {
+		msg += " with scheme " + strings.Join(schemes, " or ")
+	}
+	return stringFormat(t, func(s string) bool {
+		return isURL(s, schemes)
+	}, msg)
+}
+
+func uriFormat(t reflect.Type, param string) (Func, error) {
+	return stringFormat(t, isURI, "must be a valid URI")
+}
+
+func hostnameFormat(t reflect.Type, param string) (Func, error) {
+	return stringFormat(t, isHostname, "must be a valid hostname")
+}
+
+func fqdnFormat(t reflect.Type, param string) (Func, error) {
+	return stringFormat(t, isFQDN, "must be a fully qualified domain name")
+}
+
+// stringFormat returns a function which validates if strings of type t
+// satisfy valid.
+func stringFormat(t reflect.Type, valid func(string) bool, msg string) (Func, error) {
+	if indirectType(t).Kind() != reflect.String {
+		return nil, errUnsupported
+	}
+	return formatFunc(func(v reflect.Value) bool {
+		return valid(v.String())
+	}, msg), nil
+}
+
+// textFormat returns a function which validates if strings or []byte of
+// type t satisfy valid.
+func textFormat(t reflect.Type, valid func(string) bool, msg string) (Func, error) {
+	if isBytes(indirectType(t)) {
+		return formatFunc(func(v reflect.Value) bool {
+			return valid(string(v.Bytes()))
+		}, msg), nil
+	}
+	return stringFormat(t, valid, msg)
+}
+
+// formatFunc returns a function which validates if values satisfy valid
+// or returns an error with message msg. Nil pointers are not validated.
+func formatFunc(valid func(reflect.Value) bool, msg string) Func {
+	return func(v reflect.Value, name, param string) error {
+		v, ok := indirect(v)
+		if !ok {
+			return errSkip
+		}
+		if !valid(v) {
+			return newFieldError(v, name, param, "%s", msg)
+		}
+		return nil
+	}
+}
+
+// isEmail returns true if s is an addr-spec defined in RFC 5322 without
+// comments and folding white spaces. If idn is true, the domain can
+// contain Unicode letters and digits.
+func isEmail(s string, idn bool) bool {
+	if len(s) > maxEmailLength {
+		return false
+	}
+	at := strings.LastIndexByte(s, '@')
+	if at < 0 {
+		return false
+	}
+	local, domain := s[:at], s[at+1:]
+	if len(local) > maxLocalPartLength || !(isDotAtom(local) || isQuotedString(local)) {
+		return false
+	}
+	if strings.HasPrefix(domain, "[") && strings.HasSuffix(domain, "]") {
+		return isDomainLiteral(domain[1 : len(domain)-1])
+	}
+	return isDomain(domain, idn)
+}
+
+// isDotAtom returns true if s is dot-atom-text in RFC 5322.
+func isDotAtom(s string) bool {
+	for _, atom := range strings.Split(s, ".") {
+		if atom == "" {
+			return false
+		}
+		for i := 0; i < len(atom); i++ {
+			if !isAtext(atom[i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func isAtext(c byte) bool {
+	return isAlphaNumeric(c) || strings.IndexByte("!#$%&'*+-/=?^_`{|}~", c) >= 0
+}
+
+func isAlphaNumeric(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
+
+// isQuotedString returns true if s is quoted-string in RFC 5322.
+func isQuotedString(s string) bool {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return false
+	}
+	s = s[1 : len(s)-1]
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '\\' {
+			// quoted-pair
+			i++
+			if i >= len(s) || s[i] < ' ' || s[i] > '~' {
+				return false
+			}
+			continue
+		}
+		if c < ' ' || c > '~' || c == '"' {
+			return false
+		}
+	}
+	return true
+}
+
+// isDomainLiteral returns true if s is an IPv4 address or an IPv6 address
+// prefixed by "IPv6:" as in RFC 5321 address literals.
+func isDomainLiteral(s string) bool {
+	if strings.HasPrefix(s, "IPv6:") {
+		s = s[len("IPv6:"):]
+		return net.ParseIP(s) != nil && strings.Contains(s, ":")
+	}
+	ip := net.ParseIP(s)
+	return ip != nil && ip.To4() != nil && !strings.Contains(s, ":")
+}
+
+// isHostname returns true if s is a host name in RFC 1123.
+func isHostname(s string) bool {
+	return isDomain(s, false)
+}
+
+// isFQDN returns true if s is a host name with at least two labels,
+// optionally ending with a dot, whose top-level domain is not numeric.
+func isFQDN(s string) bool {
+	s = strings.TrimSuffix(s, ".")
+	dot := strings.LastIndexByte(s, '.')
+	if dot < 0 || !isDomain(s, false) {
+		return false
+	}
+	tld := s[dot+1:]
+	return strings.TrimLeft(tld, "0123456789") != ""
+}
+
+// isDomain returns true if s consists of labels separated by dots. Labels
+// contain letters, digits and hyphens and do not start or end with a
+// hyphen. If idn is true, Unicode letters and digits are also allowed.
+func isDomain(s string, idn bool) bool {
+	if s == "" || len(s) > maxHostnameLength {
+		return false
+	}
+	for _, label := range strings.Split(s, ".") {
+		if label == "" || utf8.RuneCountInString(label) > maxLabelLength ||
+			label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range label {
+			switch {
+			case c < utf8.RuneSelf:
+				if c != '-' && !isAlphaNumeric(byte(c)) {
+					return false
+				}
+			case !idn || !(unicode.IsLetter(c) || unicode.IsDigit(c) || unicode.IsMark(c)):
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isURL returns true if s is an absolute URL with a host. If schemes is
+// not empty, the scheme of the URL must be one of them.
+func isURL(s string, schemes []string) bool {
+	if !isURI(s) {
+		return false
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.Host == "" || u.Hostname() == "" {
+		return false
+	}
+	if len(schemes) == 0 {
+		return true
+	}
+	for _, scheme := range schemes {
+		if u.Scheme == scheme {
+			return true
+		}
+	}
+	return false
+}
+
+// isURI returns true if s is an absolute URI, which has a scheme, and does
+// not contain spaces or control characters.
+func isURI(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] <= ' ' || s[i] == 0x7f {
+			return false
+		}
+	}
+	u, err := url.Parse(s)
+	return err == nil && u.Scheme != ""
+}
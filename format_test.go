@@ -0,0 +1,191 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsEmail(t *testing.T) {
+	valid := []string{
+		"user@example.com",
+		"first.last+tag@sub.example.co.uk",
+		"!#$%&'*+-/=?^_`{|}~@example.com",
+		`"john doe"@example.com`,
+		`"a\"b"@example.com`,
+		"user@localhost",
+		"user@[192.168.0.1]",
+		"user@[IPv6:2001:db8::1]",
+		"user@xn--bcher-kva.example",
+	}
+	for _, s := range valid {
+		if !isEmail(s, false) {
+			t.Errorf("%q must be valid", s)
+		}
+	}
+	invalid := []string{
+		"",
+		"user",
+		"@example.com",
+		"user@",
+		".user@example.com",
+		"user.@example.com",
+		"us..er@example.com",
+		"us er@example.com",
+		`"a"b"@example.com`,
+		"user@-example.com",
+		"user@example..com",
+		"user@[IPv6:192.168.0.1]",
+		"user@[::1]",
+		"user@bücher.example",
+		strings.Repeat("a", 65) + "@example.com",
+		"user@" + strings.Repeat("a", 64) + ".com",
+	}
+	for _, s := range invalid {
+		if isEmail(s, false) {
+			t.Errorf("%q must be invalid", s)
+		}
+	}
+	if !isEmail("user@bücher.example", true) {
+		t.Error("IDN must be valid")
+	}
+	if isEmail("user@bü cher.example", true) {
+		t.Error("IDN with space must be invalid")
+	}
+}
+
+func TestIsURL(t *testing.T) {
+	valid := []string{
+		"http://example.com",
+		"https://example.com:8080/path?q=1#frag",
+		"ftp://user:pass@[::1]/file",
+	}
+	for _, s := range valid {
+		if !isURL(s, nil) {
+			t.Errorf("%q must be valid", s)
+		}
+	}
+	invalid := []string{
+		"",
+		"example.com",
+		"/path",
+		"mailto:user@example.com",
+		"http://",
+		"http://:80",
+		"http://exa mple.com",
+		"http://example.com/a b",
+	}
+	for _, s := range invalid {
+		if isURL(s, nil) {
+			t.Errorf("%q must be invalid", s)
+		}
+	}
+	schemes := []string{"http", "https"}
+	if !isURL("HTTPS://example.com", schemes) {
+		t.Error("https URL must be valid")
+	}
+	if isURL("ftp://example.com", schemes) {
+		t.Error("ftp URL must be invalid")
+	}
+}
+
+func TestIsURI(t *testing.T) {
+	for _, s := range []string{"mailto:user@example.com", "urn:isbn:0451450523", "http://example.com"} {
+		if !isURI(s) {
+			t.Errorf("%q must be valid", s)
+		}
+	}
+	for _, s := range []string{"", "/relative/path", "example.com", "a b:c", "%zz:x"} {
+		if isURI(s) {
+			t.Errorf("%q must be invalid", s)
+		}
+	}
+}
+
+func TestIsHostname(t *testing.T) {
+	for _, s := range []string{"localhost", "example.com", "3com.com", "a-b.c", strings.Repeat("a", 63)} {
+		if !isHostname(s) {
+			t.Errorf("%q must be valid", s)
+		}
+	}
+	for _, s := range []string{"", "-a.com", "a-.com", "a..com", "a_b.com", "example.com.", strings.Repeat("a", 64)} {
+		if isHostname(s) {
+			t.Errorf("%q must be invalid", s)
+		}
+	}
+}
+
+func TestIsFQDN(t *testing.T) {
+	for _, s := range []string{"example.com", "www.example.com.", "a.b.c.d"} {
+		if !isFQDN(s) {
+			t.Errorf("%q must be valid", s)
+		}
+	}
+	for _, s := range []string{"", "localhost", "example.com..", "1.2.3.4", ".com"} {
+		if isFQDN(s) {
+			t.Errorf("%q must be invalid", s)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	s := struct {
+		A string  `valid:"email"`
+		B string  `valid:"url=https"`
+		C string  `valid:"uri"`
+		D string  `valid:"hostname"`
+		E *string `valid:"fqdn"`
+		F string  `valid:"email=idn"`
+	}{
+		A: "user",
+		B: "http://example.com",
+		C: "urn:x",
+		D: "a_b",
+		E: strPtr("localhost"),
+		F: "user@bücher.example",
+	}
+	v := New(withBuilder("email", emailFormat), withBuilder("url", urlFormat),
+		withBuilder("uri", uriFormat), withBuilder("hostname", hostnameFormat),
+		withBuilder("fqdn", fqdnFormat))
+	err := v.Validate(&s)
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	errs := err.(Errors)
+	want := []string{
+		"A must be a valid email address",
+		"B must be a valid URL with scheme https",
+		"D must be a valid hostname",
+		"E must be a fully qualified domain name",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("unexpected errors length: %+v; want: %d", errs, len(want))
+	}
+	for i := range want {
+		if errs[i].Error() != want[i] {
+			t.Fatalf("unexpected error: %+v; want: %s", errs[i], want[i])
+		}
+	}
+}
+
+func TestFormatInvalid(t *testing.T) {
+	s := struct {
+		A int    `valid:"email"`
+		B string `valid:"email=ascii"`
+		C []byte `valid:"url"`
+	}{}
+	v := New(withBuilder("email", emailFormat), withBuilder("url", urlFormat))
+	err := v.Check(&s)
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	errs := err.(Errors)
+	if len(errs) != 3 {
+		t.Fatalf("unexpected errors length: %+v; want: %d", errs, 3)
+	}
+	if errs[0].(*TagError).Err != UnsupportedError("int") {
+		t.Fatalf("unexpected error: %+v", errs[0])
+	}
+	if errs[1].(*TagError).Err.Error() != `invalid option "ascii"` {
+		t.Fatalf("unexpected error: %+v", errs[1])
+	}
+}
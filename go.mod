@@ -0,0 +1,3 @@
+module github.com/goburrow/validator
+
+go 1.18
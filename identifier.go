@@ -0,0 +1,140 @@
+package validator
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	uuidLength = 36
+	ulidLength = 26
+	// ulidAlphabet is the Crockford's Base32 used by ULID.
+	ulidAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+)
+
+// uuidFormat returns a function validating UUIDs in the form of
+// xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx. The version of RFC 9562 UUIDs,
+// from 1 to 8, can be given in param.
+func uuidFormat(t reflect.Type, param string) (Func, error) {
+	if param == "" {
+		return textFormat(t, isUUID, "must be a valid UUID")
+	}
+	version, err := strconv.Atoi(param)
+	if err != nil || version < 1 || version > 8 {
+		return nil, &ParamError{Param: param, Kind: "version"}
+	}
+	return textFormat(t, func(s string) bool {
+		return isUUID(s) && uuidVersion(s) == version && isRFCVariant(s)
+	}, "must be a valid UUID version "+param)
+}
+
+func ulidFormat(t reflect.Type, param string) (Func, error) {
+	return textFormat(t, isULID, "must be a valid ULID")
+}
+
+func hexFormat(t reflect.Type, param string) (Func, error) {
+	return textFormat(t, isHex, "must be a valid hexadecimal string")
+}
+
+func base64Format(t reflect.Type, param string) (Func, error) {
+	return textFormat(t, isBase64, "must be a valid base64 string")
+}
+
+func base64URLFormat(t reflect.Type, param string) (Func, error) {
+	return textFormat(t, isBase64URL, "must be a valid base64url string")
+}
+
+func base32Format(t reflect.Type, param string) (Func, error) {
+	return textFormat(t, isBase32, "must be a valid base32 string")
+}
+
+func isUUID(s string) bool {
+	if len(s) != uuidLength {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		switch i {
+		case 8, 13, 18, 23:
+			if s[i] != '-' {
+				return false
+			}
+		default:
+			if !isHexDigit(s[i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// uuidVersion returns the version of UUID s.
+func uuidVersion(s string) int {
+	n, _ := strconv.ParseUint(s[14:15], 16, 8)
+	return int(n)
+}
+
+// isRFCVariant returns true if UUID s has the variant of RFC 9562.
+func isRFCVariant(s string) bool {
+	return strings.IndexByte("89abAB", s[19]) >= 0
+}
+
+func isHexDigit(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+// isULID returns true if s is a ULID encoded in case-insensitive Crockford's
+// Base32 which does not exceed the 128-bit maximum.
+func isULID(s string) bool {
+	if len(s) != ulidLength || s[0] > '7' {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(ulidAlphabet, upper(s[i])) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func upper(c byte) byte {
+	if 'a' <= c && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
+
+// isHex returns true if s is a non-empty hexadecimal encoding of bytes.
+func isHex(s string) bool {
+	return isEncoded(s, hex.DecodeString)
+}
+
+// isBase64 returns true if s is a padded standard base64 encoding.
+func isBase64(s string) bool {
+	return isEncoded(s, base64.StdEncoding.DecodeString)
+}
+
+// isBase64URL returns true if s is a URL-safe base64 encoding, padded
+// or not.
+func isBase64URL(s string) bool {
+	return isEncoded(s, base64.URLEncoding.DecodeString) ||
+		isEncoded(s, base64.RawURLEncoding.DecodeString)
+}
+
+// isBase32 returns true if s is a padded standard base32 encoding.
+func isBase32(s string) bool {
+	return isEncoded(s, base32.StdEncoding.DecodeString)
+}
+
+// isEncoded returns true if s is not empty and can be decoded. New lines,
+// which are ignored by base64 and base32 decoders, are not allowed.
+func isEncoded(s string, decode func(string) ([]byte, error)) bool {
+	if s == "" || strings.ContainsAny(s, "\r\n") {
+		return false
+	}
+	_, err := decode(s)
+	return err == nil
+}
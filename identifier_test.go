@@ -0,0 +1,136 @@
+package validator
+
+import "testing"
+
+func TestIsUUID(t *testing.T) {
+	for _, s := range []string{
+		"00000000-0000-0000-0000-000000000000",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"F47AC10B-58CC-4372-A567-0E02B2C3D479",
+	} {
+		if !isUUID(s) {
+			t.Errorf("%q must be valid", s)
+		}
+	}
+	for _, s := range []string{
+		"",
+		"6ba7b8109dad11d180b400c04fd430c8",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c",
+		"6ba7b810-9dad-11d1-80b4_00c04fd430c8",
+		"g47ac10b-58cc-4372-a567-0e02b2c3d479",
+		"{6ba7b810-9dad-11d1-80b4-00c04fd430c8}",
+	} {
+		if isUUID(s) {
+			t.Errorf("%q must be invalid", s)
+		}
+	}
+}
+
+func TestIsULID(t *testing.T) {
+	for _, s := range []string{"01ARZ3NDEKTSV4RRFFQ69G5FAV", "01arz3ndektsv4rrffq69g5fav", "7ZZZZZZZZZZZZZZZZZZZZZZZZZ"} {
+		if !isULID(s) {
+			t.Errorf("%q must be valid", s)
+		}
+	}
+	for _, s := range []string{"", "01ARZ3NDEKTSV4RRFFQ69G5FA", "01ARZ3NDEKTSV4RRFFQ69G5FAU", "01ARZ3NDEKTSV4RRFFQ69G5FAI", "8ZZZZZZZZZZZZZZZZZZZZZZZZZ"} {
+		if isULID(s) {
+			t.Errorf("%q must be invalid", s)
+		}
+	}
+}
+
+func TestIsEncoded(t *testing.T) {
+	tests := []struct {
+		fn    func(string) bool
+		valid []string
+		nok   []string
+	}{
+		{isHex, []string{"00", "deadBEEF"}, []string{"", "0", "0x00", "zz"}},
+		{isBase64, []string{"YQ==", "+/8="}, []string{"", "YQ", "-_8=", "YQ==\n"}},
+		{isBase64URL, []string{"YQ==", "YQ", "-_8"}, []string{"", "+/8=", "Y"}},
+		{isBase32, []string{"ME======", "MFRGG==="}, []string{"", "ME", "me======", "ME======\r\n"}},
+	}
+	for i, tt := range tests {
+		for _, s := range tt.valid {
+			if !tt.fn(s) {
+				t.Errorf("%d: %q must be valid", i, s)
+			}
+		}
+		for _, s := range tt.nok {
+			if tt.fn(s) {
+				t.Errorf("%d: %q must be invalid", i, s)
+			}
+		}
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	s := struct {
+		A string  `valid:"uuid"`
+		B string  `valid:"uuid=4"`
+		C string  `valid:"uuid=4"`
+		D []byte  `valid:"uuid=1"`
+		E []byte  `valid:"ulid"`
+		F *string `valid:"hex"`
+		G string  `valid:"base64"`
+		H []byte  `valid:"base64url"`
+		I string  `valid:"base32"`
+	}{
+		A: "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		B: "f47ac10b-58cc-4372-a567-0e02b2c3d479",
+		C: "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		D: []byte("6ba7b810-9dad-11d1-c0b4-00c04fd430c8"),
+		E: []byte("01ARZ3NDEKTSV4RRFFQ69G5FAV"),
+		G: "YQ",
+		H: []byte("YQ"),
+		I: "ME======",
+	}
+	v := New(withBuilder("uuid", uuidFormat), withBuilder("ulid", ulidFormat),
+		withBuilder("hex", hexFormat), withBuilder("base64", base64Format),
+		withBuilder("base64url", base64URLFormat), withBuilder("base32", base32Format))
+	err := v.Validate(&s)
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	errs := err.(Errors)
+	want := []string{
+		"C must be a valid UUID version 4",
+		"D must be a valid UUID version 1",
+		"G must be a valid base64 string",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("unexpected errors length: %+v; want: %d", errs, len(want))
+	}
+	for i := range want {
+		if errs[i].Error() != want[i] {
+			t.Fatalf("unexpected error: %+v; want: %s", errs[i], want[i])
+		}
+	}
+}
+
+func TestIdentifierInvalid(t *testing.T) {
+	s := struct {
+		A string `valid:"uuid=9"`
+		B string `valid:"uuid=v4"`
+		C int    `valid:"ulid"`
+		D []int  `valid:"hex"`
+	}{}
+	v := New(withBuilder("uuid", uuidFormat), withBuilder("ulid", ulidFormat), withBuilder("hex", hexFormat))
+	err := v.Check(&s)
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	errs := err.(Errors)
+	if len(errs) != 4 {
+		t.Fatalf("unexpected errors length: %+v; want: %d", errs, 4)
+	}
+	if errs[0].(*TagError).Err.Error() != `invalid version "9"` {
+		t.Fatalf("unexpected error: %+v", errs[0])
+	}
+	if errs[2].(*TagError).Err != UnsupportedError("int") {
+		t.Fatalf("unexpected error: %+v", errs[2])
+	}
+	if errs[3].(*TagError).Err != UnsupportedError("[]int") {
+		t.Fatalf("unexpected error: %+v", errs[3])
+	}
+}
@@ -1,120 +1,273 @@
 package validator
 
 import (
-	"fmt"
 	"reflect"
-	"strconv"
+	"strings"
+	"time"
 )
 
-func min(v reflect.Value, name, param string) error {
-	// Resolve pointer
-	for v.Kind() == reflect.Ptr {
-		// Allow nil
-		if v.IsNil() {
-			return nil
-		}
-		v = v.Elem()
+// valueClass is a class of values compared by bound functions.
+type valueClass int
+
+const (
+	numberClass valueClass = iota
+	lengthClass
+	timeClass
+)
+
+// bound is a comparison of a value with a parameter.
+type bound struct {
+	ok      func(c int) bool // c is the result of comparing value with parameter
+	msg     string           // message format for numbers
+	lenMsg  string           // message format for lengths
+	timeMsg string           // message format for time.Time
+}
+
+var (
+	minBound = bound{
+		ok:      func(c int) bool { return c >= 0 },
+		msg:     "must not be less than %s",
+		lenMsg:  "must have length not less than %s",
+		timeMsg: "must not be before %s",
 	}
-	switch v.Kind() {
-	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
-		if v.Len() < int(parseInt(param)) {
-			return fmt.Errorf("%s must have length not less than %s (was %v)", name, param, v.Len())
+	maxBound = bound{
+		ok:      func(c int) bool { return c <= 0 },
+		msg:     "must not be greater than %s",
+		lenMsg:  "must have length not greater than %s",
+		timeMsg: "must not be after %s",
+	}
+	gtBound = bound{
+		ok:      func(c int) bool { return c > 0 },
+		msg:     "must be greater than %s",
+		lenMsg:  "must have length greater than %s",
+		timeMsg: "must be after %s",
+	}
+	ltBound = bound{
+		ok:      func(c int) bool { return c < 0 },
+		msg:     "must be less than %s",
+		lenMsg:  "must have length less than %s",
+		timeMsg: "must be before %s",
+	}
+	lenBound = bound{
+		ok:     func(c int) bool { return c == 0 },
+		lenMsg: "must have length %s",
+	}
+	// notLenBound and notBetweenBound only provide messages of negated
+	// 'len' and 'between'.
+	notLenBound = bound{
+		lenMsg: "must not have length %s",
+	}
+	notBetweenBound = bound{
+		msg:     "must not be between %s and %s",
+		lenMsg:  "must have length not between %s and %s",
+		timeMsg: "must not be between %s and %s",
+	}
+)
+
+// message returns the message format of bound b for values of class c.
+func (b *bound) message(c valueClass) string {
+	switch c {
+	case lengthClass:
+		return b.lenMsg
+	case timeClass:
+		return b.timeMsg
+	}
+	return b.msg
+}
+
+func (a *Validator) min(t reflect.Type, param string) (Func, error) {
+	return a.buildBound(t, param, &minBound)
+}
+
+func (a *Validator) max(t reflect.Type, param string) (Func, error) {
+	return a.buildBound(t, param, &maxBound)
+}
+
+func (a *Validator) gt(t reflect.Type, param string) (Func, error) {
+	return a.buildBound(t, param, &gtBound)
+}
+
+func (a *Validator) lt(t reflect.Type, param string) (Func, error) {
+	return a.buildBound(t, param, &ltBound)
+}
+
+func (a *Validator) length(t reflect.Type, param string) (Func, error) {
+	return a.buildBound(t, param, &lenBound)
+}
+
+// buildBound returns a function which validates values of type t with
+// bound b and parameter param. Lengths are compared for strings, slices,
+// arrays and maps. Nil pointers are not validated.
+func (a *Validator) buildBound(t reflect.Type, param string, b *bound) (Func, error) {
+	cmp, class, err := a.comparator(t, param)
+	msg := b.message(class)
+	if msg == "" {
+		return nil, errUnsupported
+	}
+	if err != nil {
+		return nil, err
+	}
+	msg += " (was %v)"
+	return func(v reflect.Value, name, param string) error {
+		v, ok := indirect(v)
+		if !ok {
+			return errSkip
 		}
-		return nil
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		if v.Int() < parseInt(param) {
-			return fmt.Errorf("%s must not be less than %s (was %v)", name, param, v.Int())
+		c, ok := cmp(v)
+		if !ok {
+			return errSkip
 		}
-		return nil
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-		if v.Uint() < parseUint(param) {
-			return fmt.Errorf("%s must not be less than %s (was %v)", name, param, v.Uint())
+		if !b.ok(c) {
+			return newFieldError(v, name, param, msg, param, valueOf(v, class))
 		}
 		return nil
-	case reflect.Float32, reflect.Float64:
-		if v.Float() < parseFloat(param) {
-			return fmt.Errorf("%s must not be less than %s (was %v)", name, param, v.Float())
+	}, nil
+}
+
+// between returns a function which validates if values of type t are
+// within the lower and upper bounds, inclusive, separated by space in param.
+func (a *Validator) between(t reflect.Type, param string) (Func, error) {
+	bounds := strings.Fields(param)
+	if len(bounds) != 2 {
+		return nil, &ParamError{Param: param, Kind: "bounds"}
+	}
+	lo, class, err := a.comparator(t, bounds[0])
+	if err != nil {
+		return nil, err
+	}
+	hi, hiClass, err := a.comparator(t, bounds[1])
+	if err != nil {
+		return nil, err
+	}
+	if class != hiClass {
+		return nil, &ParamError{Param: param, Kind: "bounds"}
+	}
+	msg := "must be between "
+	if class == lengthClass {
+		msg = "must have length between "
+	}
+	msg += bounds[0] + " and " + bounds[1] + " (was %v)"
+	return func(v reflect.Value, name, param string) error {
+		v, ok := indirect(v)
+		if !ok {
+			return errSkip
+		}
+		l, lok := lo(v)
+		h, hok := hi(v)
+		if !lok || !hok {
+			return errSkip
+		}
+		if l < 0 || h > 0 {
+			return newFieldError(v, name, param, msg, valueOf(v, class))
 		}
 		return nil
-	}
-	return UnsupportedError(name)
+	}, nil
 }
 
-func max(v reflect.Value, name, param string) error {
-	// Resolve pointer
-	for v.Kind() == reflect.Ptr {
-		// Allow nil
-		if v.IsNil() {
-			return nil
+// comparator returns a function comparing values of type t with s, which
+// is parsed according to t, and the class of the values compared. The
+// function returns false if the value cannot be compared. Strings are
+// compared as time when s is a time parameter instead of a number.
+func (a *Validator) comparator(t reflect.Type, s string) (cmp func(reflect.Value) (int, bool), class valueClass, err error) {
+	t = indirectType(t)
+	switch t {
+	case bigIntType, bigRatType, bigFloatType, jsonNumberType:
+		cmp, err := bigComparator(s)
+		return cmp, numberClass, err
+	case durationType:
+		// Numbers without unit are nanoseconds as for other integers.
+		if _, err := parseNumber(s); err != nil {
+			d, err := parseDuration(s)
+			if err != nil {
+				return nil, numberClass, err
+			}
+			return func(v reflect.Value) (int, bool) {
+				return compareInt(v.Int(), int64(d)), true
+			}, numberClass, nil
 		}
-		v = v.Elem()
+	case timeType:
+		tm, _, err := a.parseTime(s)
+		if err != nil {
+			return nil, timeClass, err
+		}
+		return func(v reflect.Value) (int, bool) {
+			return compareTime(v.Interface().(time.Time), tm()), true
+		}, timeClass, nil
 	}
-	switch v.Kind() {
+	switch t.Kind() {
 	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
-		if v.Len() > int(parseInt(param)) {
-			return fmt.Errorf("%s must have length not greater than %s (was %v)", name, param, v.Len())
+		n, err := parseNumber(s)
+		if err != nil {
+			if t.Kind() == reflect.String {
+				if cmp, err := a.timeComparator(s); err == nil {
+					return cmp, timeClass, nil
+				}
+			}
+			return nil, lengthClass, err
 		}
-		return nil
+		return func(v reflect.Value) (int, bool) {
+			return n.compareInt(int64(v.Len())), true
+		}, lengthClass, nil
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		if v.Int() > parseInt(param) {
-			return fmt.Errorf("%s must not be greater than %s (was %v)", name, param, v.Int())
+		n, err := parseNumber(s)
+		if err != nil {
+			return nil, numberClass, err
 		}
-		return nil
+		return func(v reflect.Value) (int, bool) {
+			return n.compareInt(v.Int()), true
+		}, numberClass, nil
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-		if v.Uint() > parseUint(param) {
-			return fmt.Errorf("%s must not be greater than %s (was %v)", name, param, v.Uint())
+		n, err := parseNumber(s)
+		if err != nil {
+			return nil, numberClass, err
 		}
-		return nil
+		return func(v reflect.Value) (int, bool) {
+			return n.compareUint(v.Uint()), true
+		}, numberClass, nil
 	case reflect.Float32, reflect.Float64:
-		if v.Float() > parseFloat(param) {
-			return fmt.Errorf("%s must not be greater than %s (was %v)", name, param, v.Float())
+		n, err := parseNumber(s)
+		if err != nil {
+			return nil, numberClass, err
 		}
-		return nil
+		if t.Kind() == reflect.Float32 {
+			n = n.float32()
+		}
+		return func(v reflect.Value) (int, bool) {
+			return n.compareFloat(v.Float()), true
+		}, numberClass, nil
 	}
-	return UnsupportedError(name)
+	return nil, numberClass, errUnsupported
 }
 
-var defaultIntCache intCache
-
-func parseInt(s string) int64 {
-	val, ok := defaultIntCache.get(s)
-	if ok {
-		return val
+// classOf returns the class of values of type t compared with parameter
+// param, or its first bound for 'between'.
+func (a *Validator) classOf(t reflect.Type, param string) valueClass {
+	if bounds := strings.Fields(param); len(bounds) > 0 {
+		param = bounds[0]
 	}
-	val, err := strconv.ParseInt(s, 0, 0)
-	if err != nil {
-		panic(err)
-	}
-	defaultIntCache.save(s, val)
-	return val
+	_, class, _ := a.comparator(t, param)
+	return class
 }
 
-var defaultUintCache uintCache
-
-func parseUint(s string) uint64 {
-	val, ok := defaultUintCache.get(s)
-	if ok {
-		return val
+// valueOf returns the value, or its length for lengthClass, to be
+// reported in errors.
+func valueOf(v reflect.Value, class valueClass) interface{} {
+	if class == lengthClass {
+		return v.Len()
 	}
-	val, err := strconv.ParseUint(s, 0, 0)
-	if err != nil {
-		panic(err)
+	switch v.Type() {
+	case durationType:
+		return time.Duration(v.Int())
+	case bigIntType, bigRatType, bigFloatType:
+		return addressOf(v)
 	}
-	defaultUintCache.save(s, val)
-	return val
-}
-
-var defaultFloatCache floatCache
-
-func parseFloat(s string) float64 {
-	val, ok := defaultFloatCache.get(s)
-	if ok {
-		return val
-	}
-	val, err := strconv.ParseFloat(s, 64)
-	if err != nil {
-		panic(err)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
 	}
-	defaultFloatCache.save(s, val)
-	return val
+	return v.Interface()
 }
@@ -1,6 +1,10 @@
 package validator
 
-import "testing"
+import (
+	"errors"
+	"math"
+	"testing"
+)
 
 func mIntVal(v int) *int {
 	return &v
@@ -46,7 +50,7 @@ func TestMin(t *testing.T) {
 			"b": 2,
 		},
 	}
-	v := New(WithFunc("min", min))
+	v := New(withMethod("min", (*Validator).min))
 	err := v.Validate(&s)
 	if err == nil {
 		t.Fatal("error expected")
@@ -86,7 +90,7 @@ func TestMinUnsupported(t *testing.T) {
 		s1{0},
 		false,
 	}
-	v := New(WithFunc("min", min))
+	v := New(withMethod("min", (*Validator).min))
 	err := v.Validate(&s)
 	if err == nil {
 		t.Fatal("error expected")
@@ -139,7 +143,7 @@ func TestMax(t *testing.T) {
 			"b": 2,
 		},
 	}
-	v := New(WithFunc("max", max))
+	v := New(withMethod("max", (*Validator).max))
 	err := v.Validate(&s)
 	if err == nil {
 		t.Fatal("error expected")
@@ -179,7 +183,7 @@ func TestMaxUnsupported(t *testing.T) {
 		s1{0},
 		false,
 	}
-	v := New(WithFunc("max", max))
+	v := New(withMethod("max", (*Validator).max))
 	err := v.Validate(&s)
 	if err == nil {
 		t.Fatal("error expected")
@@ -195,3 +199,218 @@ func TestMaxUnsupported(t *testing.T) {
 		t.Fatalf("unexpected error: %+v", errs[1])
 	}
 }
+
+func TestMinMaxInvalidParam(t *testing.T) {
+	s := struct {
+		A int    `valid:"min=a"`
+		B uint   `valid:"max=-1"`
+		C string `valid:"max=1"`
+	}{
+		C: "cc",
+	}
+	v := New(withMethod("min", (*Validator).min), withMethod("max", (*Validator).max))
+	err := v.Validate(&s)
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	errs := err.(Errors)
+	if len(errs) != 3 {
+		t.Fatalf("unexpected errors length: %+v; want: %d", errs, 3)
+	}
+	if errs[1].Error() != "B must not be greater than -1 (was 0)" {
+		t.Fatalf("unexpected error: %+v", errs[1])
+	}
+	if errs[2].Error() != "C must have length not greater than 1 (was 2)" {
+		t.Fatalf("unexpected error: %+v", errs[2])
+	}
+}
+
+func TestGtLt(t *testing.T) {
+	s := struct {
+		A int     `valid:"gt=0"`
+		B int     `valid:"gt=0"`
+		C uint    `valid:"lt=10"`
+		D float64 `valid:"lt=1.5"`
+		E string  `valid:"gt=1"`
+		F []int   `valid:"lt=2"`
+		G *int    `valid:"gt=0"`
+	}{
+		A: 1,
+		B: 0,
+		C: 10,
+		D: 1.4,
+		E: "a",
+		F: []int{1, 2},
+	}
+	v := New(withMethod("gt", (*Validator).gt), withMethod("lt", (*Validator).lt))
+	err := v.Validate(&s)
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	errs := err.(Errors)
+	want := []string{
+		"B must be greater than 0 (was 0)",
+		"C must be less than 10 (was 10)",
+		"E must have length greater than 1 (was 1)",
+		"F must have length less than 2 (was 2)",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("unexpected errors length: %+v; want: %d", errs, len(want))
+	}
+	for i := range want {
+		if errs[i].Error() != want[i] {
+			t.Fatalf("unexpected error: %+v; want: %s", errs[i], want[i])
+		}
+	}
+}
+
+func TestLength(t *testing.T) {
+	s := struct {
+		A string         `valid:"len=2"`
+		B string         `valid:"len=2"`
+		C []byte         `valid:"len=0"`
+		D map[string]int `valid:"len=1"`
+		E [3]int         `valid:"len=3"`
+	}{
+		A: "ab",
+		B: "abc",
+		C: []byte{1},
+	}
+	v := New(withMethod("len", (*Validator).length))
+	err := v.Validate(&s)
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	errs := err.(Errors)
+	want := []string{
+		"B must have length 2 (was 3)",
+		"C must have length 0 (was 1)",
+		"D must have length 1 (was 0)",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("unexpected errors length: %+v; want: %d", errs, len(want))
+	}
+	for i := range want {
+		if errs[i].Error() != want[i] {
+			t.Fatalf("unexpected error: %+v; want: %s", errs[i], want[i])
+		}
+	}
+}
+
+func TestLengthUnsupported(t *testing.T) {
+	s := struct {
+		A int `valid:"len=1"`
+	}{}
+	v := New(withMethod("len", (*Validator).length))
+	err := v.Check(&s)
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	errs := err.(Errors)
+	if len(errs) != 1 || errs[0].(*TagError).Err != UnsupportedError("int") {
+		t.Fatalf("unexpected errors: %+v", errs)
+	}
+}
+
+func TestBetween(t *testing.T) {
+	s := struct {
+		A int     `valid:"between=1 10"`
+		B int     `valid:"between=1 10"`
+		C int     `valid:"between=-5 -1"`
+		D uint8   `valid:"between=0x10 0x20"`
+		E float32 `valid:"between=0.5 1"`
+		F string  `valid:"between=2 3"`
+		G *int    `valid:"between=1 2"`
+	}{
+		A: 10,
+		B: 11,
+		C: 0,
+		D: 0x10,
+		E: 0.4,
+		F: "abcd",
+	}
+	v := New(withMethod("between", (*Validator).between))
+	err := v.Validate(&s)
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	errs := err.(Errors)
+	want := []string{
+		"B must be between 1 and 10 (was 11)",
+		"C must be between -5 and -1 (was 0)",
+		"E must be between 0.5 and 1 (was 0.4000000059604645)",
+		"F must have length between 2 and 3 (was 4)",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("unexpected errors length: %+v; want: %d", errs, len(want))
+	}
+	for i := range want {
+		if errs[i].Error() != want[i] {
+			t.Fatalf("unexpected error: %+v; want: %s", errs[i], want[i])
+		}
+	}
+}
+
+func TestBetweenInvalidParam(t *testing.T) {
+	s := struct {
+		A int  `valid:"between=1"`
+		B int  `valid:"between=1 2 3"`
+		C uint `valid:"between=a 2"`
+	}{}
+	v := New(withMethod("between", (*Validator).between))
+	err := v.Check(&s)
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	errs := err.(Errors)
+	if len(errs) != 3 {
+		t.Fatalf("unexpected errors length: %+v; want: %d", errs, 3)
+	}
+	if e, ok := errs[0].(*TagError).Err.(*ParamError); !ok || e.Error() != `invalid bounds "1"` {
+		t.Fatalf("unexpected error: %+v", errs[0])
+	}
+}
+
+func TestBoundExact(t *testing.T) {
+	s := struct {
+		A uint    `valid:"min=-1"`
+		B uint8   `valid:"gt=-1"`
+		C uint    `valid:"max=-1"`
+		D int64   `valid:"max=18446744073709551615"`
+		E float64 `valid:"min=9007199254740993"`
+		F float32 `valid:"max=19.53"`
+		G int     `valid:"min=1.5"`
+		H uint64  `valid:"lt=1e19"`
+		I string  `valid:"min=-1"`
+	}{
+		D: math.MaxInt64,
+		E: 9007199254740992,
+		F: 19.53,
+		G: 1,
+		H: 1e19,
+	}
+	err := Default().Validate(&s)
+	assertNOK(t, err,
+		"C must not be greater than -1 (was 0)",
+		"E must not be less than 9007199254740993 (was 9.007199254740992e+15)",
+		"G must not be less than 1.5 (was 1)",
+		"H must be less than 1e19 (was 10000000000000000000)",
+	)
+}
+
+func TestBoundParamError(t *testing.T) {
+	s := struct {
+		A int `valid:"min=1x"`
+	}{}
+	err := Default().Check(&s)
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	var pe *ParamError
+	if !errors.As(err.(Errors)[0], &pe) {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	if pe.Param != "1x" || pe.Kind != "number" {
+		t.Fatalf("unexpected error: %+v", pe)
+	}
+}
@@ -0,0 +1,159 @@
+package validator
+
+import (
+	"net"
+	"net/netip"
+	"reflect"
+	"strconv"
+)
+
+var (
+	ipType           = reflect.TypeOf(net.IP(nil))
+	hardwareAddrType = reflect.TypeOf(net.HardwareAddr(nil))
+	addrType         = reflect.TypeOf(netip.Addr{})
+	prefixType       = reflect.TypeOf(netip.Prefix{})
+	addrPortType     = reflect.TypeOf(netip.AddrPort{})
+)
+
+const maxPort = 65535
+
+func ipFormat(t reflect.Type, param string) (Func, error) {
+	return buildIP(t, func(a netip.Addr) bool {
+		return true
+	}, "must be a valid IP address")
+}
+
+func ipv4Format(t reflect.Type, param string) (Func, error) {
+	return buildIP(t, netip.Addr.Is4, "must be a valid IPv4 address")
+}
+
+func ipv6Format(t reflect.Type, param string) (Func, error) {
+	return buildIP(t, netip.Addr.Is6, "must be a valid IPv6 address")
+}
+
+// buildIP returns a function which validates if IP addresses in strings,
+// net.IP or netip.Addr of type t satisfy valid.
+func buildIP(t reflect.Type, valid func(netip.Addr) bool, msg string) (Func, error) {
+	addr := addrFunc(indirectType(t))
+	if addr == nil {
+		return nil, errUnsupported
+	}
+	return formatFunc(func(v reflect.Value) bool {
+		a, ok := addr(v)
+		return ok && valid(a)
+	}, msg), nil
+}
+
+// addrFunc returns a function converting values of type t to netip.Addr,
+// or nil if type t is not supported. IPv4-mapped IPv6 addresses in net.IP
+// are converted to IPv4 addresses as net.IP does not distinguish them.
+func addrFunc(t reflect.Type) func(reflect.Value) (netip.Addr, bool) {
+	switch {
+	case t == ipType:
+		return func(v reflect.Value) (netip.Addr, bool) {
+			a, ok := netip.AddrFromSlice(v.Bytes())
+			return a.Unmap(), ok
+		}
+	case t == addrType:
+		return func(v reflect.Value) (netip.Addr, bool) {
+			a := v.Interface().(netip.Addr)
+			return a, a.IsValid()
+		}
+	case t.Kind() == reflect.String:
+		return func(v reflect.Value) (netip.Addr, bool) {
+			a, err := netip.ParseAddr(v.String())
+			return a, err == nil
+		}
+	}
+	return nil
+}
+
+// cidrFormat returns a function validating IP prefixes in CIDR notation,
+// e.g. "192.168.0.0/16", in strings or netip.Prefix.
+func cidrFormat(t reflect.Type, param string) (Func, error) {
+	const msg = "must be a valid CIDR notation"
+	switch t = indirectType(t); {
+	case t == prefixType:
+		return formatFunc(func(v reflect.Value) bool {
+			return v.Interface().(netip.Prefix).IsValid()
+		}, msg), nil
+	case t.Kind() == reflect.String:
+		return formatFunc(func(v reflect.Value) bool {
+			_, err := netip.ParsePrefix(v.String())
+			return err == nil
+		}, msg), nil
+	}
+	return nil, errUnsupported
+}
+
+// macFormat returns a function validating IEEE 802 MAC-48, EUI-48, EUI-64
+// or 20-octet IP over InfiniBand addresses in strings or net.HardwareAddr.
+func macFormat(t reflect.Type, param string) (Func, error) {
+	const msg = "must be a valid MAC address"
+	switch t = indirectType(t); {
+	case t == hardwareAddrType:
+		return formatFunc(func(v reflect.Value) bool {
+			switch v.Len() {
+			case 6, 8, 20:
+				return true
+			}
+			return false
+		}, msg), nil
+	case t.Kind() == reflect.String:
+		return formatFunc(func(v reflect.Value) bool {
+			_, err := net.ParseMAC(v.String())
+			return err == nil
+		}, msg), nil
+	}
+	return nil, errUnsupported
+}
+
+// portFormat returns a function validating port numbers from 1 to 65535
+// in strings or integers.
+func portFormat(t reflect.Type, param string) (Func, error) {
+	const msg = "must be a valid port number"
+	switch indirectType(t).Kind() {
+	case reflect.String:
+		return stringFormat(t, isPort, msg)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return formatFunc(func(v reflect.Value) bool {
+			return v.Int() > 0 && v.Int() <= maxPort
+		}, msg), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return formatFunc(func(v reflect.Value) bool {
+			return v.Uint() > 0 && v.Uint() <= maxPort
+		}, msg), nil
+	}
+	return nil, errUnsupported
+}
+
+// hostPortFormat returns a function validating a host and port in strings,
+// e.g. "example.com:80" or "[::1]:443", or netip.AddrPort. The host can be
+// omitted in strings, e.g. ":8080".
+func hostPortFormat(t reflect.Type, param string) (Func, error) {
+	const msg = "must be a valid host and port"
+	if indirectType(t) == addrPortType {
+		return formatFunc(func(v reflect.Value) bool {
+			ap := v.Interface().(netip.AddrPort)
+			return ap.IsValid() && ap.Port() != 0
+		}, msg), nil
+	}
+	return stringFormat(t, isHostPort, msg)
+}
+
+func isPort(s string) bool {
+	n, err := strconv.ParseUint(s, 10, 16)
+	return err == nil && n > 0
+}
+
+func isHostPort(s string) bool {
+	host, port, err := net.SplitHostPort(s)
+	if err != nil || !isPort(port) {
+		return false
+	}
+	if host == "" || isHostname(host) {
+		return true
+	}
+	_, err = netip.ParseAddr(host)
+	return err == nil
+}
@@ -0,0 +1,175 @@
+package validator
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+)
+
+func TestIP(t *testing.T) {
+	s := struct {
+		A string     `valid:"ip"`
+		B string     `valid:"ip"`
+		C string     `valid:"ipv4"`
+		D string     `valid:"ipv4"`
+		E string     `valid:"ipv6"`
+		F string     `valid:"ipv6"`
+		G net.IP     `valid:"ipv4"`
+		H net.IP     `valid:"ipv6"`
+		I net.IP     `valid:"ip"`
+		J netip.Addr `valid:"ipv6"`
+		K netip.Addr `valid:"ip"`
+		L *string    `valid:"ip"`
+	}{
+		A: "192.168.0.1",
+		B: "192.168.0.256",
+		C: "10.0.0.1",
+		D: "::1",
+		E: "fe80::1%eth0",
+		F: "10.0.0.1",
+		G: net.ParseIP("10.0.0.1"),
+		H: net.ParseIP("10.0.0.1"),
+		I: net.IP{1, 2, 3},
+		J: netip.MustParseAddr("2001:db8::1"),
+	}
+	v := New(withBuilder("ip", ipFormat), withBuilder("ipv4", ipv4Format), withBuilder("ipv6", ipv6Format))
+	err := v.Validate(&s)
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	errs := err.(Errors)
+	want := []string{
+		"B must be a valid IP address",
+		"D must be a valid IPv4 address",
+		"F must be a valid IPv6 address",
+		"H must be a valid IPv6 address",
+		"I must be a valid IP address",
+		"K must be a valid IP address",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("unexpected errors length: %+v; want: %d", errs, len(want))
+	}
+	for i := range want {
+		if errs[i].Error() != want[i] {
+			t.Fatalf("unexpected error: %+v; want: %s", errs[i], want[i])
+		}
+	}
+}
+
+func TestCIDRMAC(t *testing.T) {
+	s := struct {
+		A string           `valid:"cidr"`
+		B string           `valid:"cidr"`
+		C netip.Prefix     `valid:"cidr"`
+		D netip.Prefix     `valid:"cidr"`
+		E string           `valid:"mac"`
+		F string           `valid:"mac"`
+		G net.HardwareAddr `valid:"mac"`
+		H net.HardwareAddr `valid:"mac"`
+	}{
+		A: "2001:db8::/32",
+		B: "10.0.0.0/33",
+		C: netip.MustParsePrefix("10.0.0.0/8"),
+		E: "00:00:5e:00:53:01",
+		F: "00:00:5e:00:53",
+		G: net.HardwareAddr{0, 0, 0x5e, 0, 0x53, 1},
+		H: net.HardwareAddr{0, 0, 0x5e},
+	}
+	v := New(withBuilder("cidr", cidrFormat), withBuilder("mac", macFormat))
+	err := v.Validate(&s)
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	errs := err.(Errors)
+	want := []string{
+		"B must be a valid CIDR notation",
+		"D must be a valid CIDR notation",
+		"F must be a valid MAC address",
+		"H must be a valid MAC address",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("unexpected errors length: %+v; want: %d", errs, len(want))
+	}
+	for i := range want {
+		if errs[i].Error() != want[i] {
+			t.Fatalf("unexpected error: %+v; want: %s", errs[i], want[i])
+		}
+	}
+}
+
+func TestPortHostPort(t *testing.T) {
+	s := struct {
+		A string         `valid:"port"`
+		B string         `valid:"port"`
+		C int            `valid:"port"`
+		D uint16         `valid:"port"`
+		E string         `valid:"hostport"`
+		F string         `valid:"hostport"`
+		G string         `valid:"hostport"`
+		H string         `valid:"hostport"`
+		I string         `valid:"hostport"`
+		J netip.AddrPort `valid:"hostport"`
+		K netip.AddrPort `valid:"hostport"`
+	}{
+		A: "8080",
+		B: "65536",
+		C: 0,
+		D: 443,
+		E: "example.com:80",
+		F: "[::1]:443",
+		G: ":8080",
+		H: "example.com",
+		I: "a_b:80",
+		J: netip.MustParseAddrPort("10.0.0.1:53"),
+		K: netip.AddrPortFrom(netip.MustParseAddr("10.0.0.1"), 0),
+	}
+	v := New(withBuilder("port", portFormat), withBuilder("hostport", hostPortFormat))
+	err := v.Validate(&s)
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	errs := err.(Errors)
+	want := []string{
+		"B must be a valid port number",
+		"C must be a valid port number",
+		"H must be a valid host and port",
+		"I must be a valid host and port",
+		"K must be a valid host and port",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("unexpected errors length: %+v; want: %d", errs, len(want))
+	}
+	for i := range want {
+		if errs[i].Error() != want[i] {
+			t.Fatalf("unexpected error: %+v; want: %s", errs[i], want[i])
+		}
+	}
+}
+
+func TestNetworkUnsupported(t *testing.T) {
+	s := struct {
+		A int          `valid:"ip"`
+		B []byte       `valid:"ipv4"`
+		C netip.Addr   `valid:"cidr"`
+		D net.IP       `valid:"mac"`
+		E float64      `valid:"port"`
+		F netip.Prefix `valid:"hostport"`
+	}{}
+	v := New(withBuilder("ip", ipFormat), withBuilder("ipv4", ipv4Format),
+		withBuilder("cidr", cidrFormat), withBuilder("mac", macFormat),
+		withBuilder("port", portFormat), withBuilder("hostport", hostPortFormat))
+	err := v.Check(&s)
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	errs := err.(Errors)
+	want := []string{"int", "[]uint8", "netip.Addr", "net.IP", "float64", "netip.Prefix"}
+	if len(errs) != len(want) {
+		t.Fatalf("unexpected errors length: %+v; want: %d", errs, len(want))
+	}
+	for i := range want {
+		if errs[i].(*TagError).Err != UnsupportedError(want[i]) {
+			t.Fatalf("unexpected error: %+v; want: %s", errs[i], want[i])
+		}
+	}
+}
@@ -1,42 +1,51 @@
 package validator
 
-import (
-	"errors"
-	"reflect"
-)
+import "reflect"
 
-func notEmpty(v reflect.Value, name, param string) error {
-	switch v.Kind() {
+func notEmpty(t reflect.Type, param string) (Func, error) {
+	switch t.Kind() {
 	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
-		if v.Len() == 0 {
-			return errors.New(name + " must not be empty")
-		}
-		return nil
+		return func(v reflect.Value, name, param string) error {
+			if v.Len() == 0 {
+				return newFieldError(v, name, param, "must not be empty")
+			}
+			return nil
+		}, nil
 	case reflect.Bool:
-		if !v.Bool() {
-			return errors.New(name + " must not be false")
-		}
-		return nil
+		return func(v reflect.Value, name, param string) error {
+			if !v.Bool() {
+				return newFieldError(v, name, param, "must not be false")
+			}
+			return nil
+		}, nil
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		if v.Int() == 0 {
-			return errors.New(name + " must not be zero")
-		}
-		return nil
+		return func(v reflect.Value, name, param string) error {
+			if v.Int() == 0 {
+				return newFieldError(v, name, param, "must not be zero")
+			}
+			return nil
+		}, nil
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-		if v.Uint() == 0 {
-			return errors.New(name + " must not be zero")
-		}
-		return nil
+		return func(v reflect.Value, name, param string) error {
+			if v.Uint() == 0 {
+				return newFieldError(v, name, param, "must not be zero")
+			}
+			return nil
+		}, nil
 	case reflect.Float32, reflect.Float64:
-		if v.Float() == 0 {
-			return errors.New(name + " must not be zero")
-		}
-		return nil
+		return func(v reflect.Value, name, param string) error {
+			if v.Float() == 0 {
+				return newFieldError(v, name, param, "must not be zero")
+			}
+			return nil
+		}, nil
 	case reflect.Interface, reflect.Ptr:
-		if v.IsNil() {
-			return errors.New(name + " must not be nil")
-		}
-		return nil
+		return func(v reflect.Value, name, param string) error {
+			if v.IsNil() {
+				return newFieldError(v, name, param, "must not be nil")
+			}
+			return nil
+		}, nil
 	}
-	return UnsupportedError(name)
+	return nil, errUnsupported
 }
@@ -31,7 +31,7 @@ func TestNotEmpty(t *testing.T) {
 		F: nil,
 		U: t,
 	}
-	v := New(WithFunc("notempty", notEmpty))
+	v := New(withBuilder("notempty", notEmpty))
 	err := v.Validate(&s)
 	if err == nil {
 		t.Fatal("error expected")
@@ -69,7 +69,7 @@ func TestNotEmptyUnsupported(t *testing.T) {
 	}{
 		s1{0},
 	}
-	v := New(WithFunc("notempty", notEmpty))
+	v := New(withBuilder("notempty", notEmpty))
 	err := v.Validate(&s)
 	if err == nil {
 		t.Fatal("error expected")
@@ -0,0 +1,113 @@
+package validator
+
+import (
+	"reflect"
+	"strings"
+)
+
+func oneOf(t reflect.Type, param string) (Func, error) {
+	return buildOneOf(t, param, false)
+}
+
+func oneOfCI(t reflect.Type, param string) (Func, error) {
+	return buildOneOf(t, param, true)
+}
+
+// buildOneOf returns a function which validates if a string or number
+// equals to any of the values separated by spaces in param. Strings are
+// compared case-insensitively if foldCase is true.
+func buildOneOf(t reflect.Type, param string, foldCase bool) (Func, error) {
+	values := strings.Fields(param)
+	if len(values) == 0 {
+		return nil, &ParamError{Param: param, Kind: "values"}
+	}
+	msg := "must be one of [" + strings.Join(values, " ") + "] (was %v)"
+	kind := indirectType(t).Kind()
+	if foldCase && kind != reflect.String {
+		return nil, errUnsupported
+	}
+	switch kind {
+	case reflect.String:
+		return func(v reflect.Value, name, param string) error {
+			v, ok := indirect(v)
+			if !ok {
+				return errSkip
+			}
+			s := v.String()
+			for _, val := range values {
+				if s == val || (foldCase && strings.EqualFold(s, val)) {
+					return nil
+				}
+			}
+			return newFieldError(v, name, param, msg, s)
+		}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		nums := make([]int64, len(values))
+		for i, val := range values {
+			n, err := parseInt(val)
+			if err != nil {
+				return nil, err
+			}
+			nums[i] = n
+		}
+		return func(v reflect.Value, name, param string) error {
+			v, ok := indirect(v)
+			if !ok {
+				return errSkip
+			}
+			for _, n := range nums {
+				if v.Int() == n {
+					return nil
+				}
+			}
+			return newFieldError(v, name, param, msg, v.Int())
+		}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		nums := make([]uint64, len(values))
+		for i, val := range values {
+			n, err := parseUint(val)
+			if err != nil {
+				return nil, err
+			}
+			nums[i] = n
+		}
+		return func(v reflect.Value, name, param string) error {
+			v, ok := indirect(v)
+			if !ok {
+				return errSkip
+			}
+			for _, n := range nums {
+				if v.Uint() == n {
+					return nil
+				}
+			}
+			return newFieldError(v, name, param, msg, v.Uint())
+		}, nil
+	case reflect.Float32, reflect.Float64:
+		nums := make([]float64, len(values))
+		for i, val := range values {
+			n, err := parseFloat(val)
+			if err != nil {
+				return nil, err
+			}
+			if kind == reflect.Float32 {
+				// Compare with values as precise as float32 fields.
+				n = float64(float32(n))
+			}
+			nums[i] = n
+		}
+		return func(v reflect.Value, name, param string) error {
+			v, ok := indirect(v)
+			if !ok {
+				return errSkip
+			}
+			for _, n := range nums {
+				if v.Float() == n {
+					return nil
+				}
+			}
+			return newFieldError(v, name, param, msg, v.Float())
+		}, nil
+	}
+	return nil, errUnsupported
+}
@@ -0,0 +1,83 @@
+package validator
+
+import "testing"
+
+func TestOneOf(t *testing.T) {
+	type s1 struct {
+		Status   string   `valid:"oneof=active suspended"`
+		Role     *string  `valid:"oneofci='admin user'"`
+		Code     int8     `valid:"oneof=-1 0 0x10"`
+		Level    uint     `valid:"oneof=1 2 3"`
+		Ratio    float32  `valid:"oneof=0.5 1"`
+		Currency string   `valid:"!oneof=XXX"`
+		Tags     []string `valid:"dive,oneof=a b"`
+	}
+	s := s1{
+		Status:   "deleted",
+		Role:     strPtr("Root"),
+		Code:     16,
+		Level:    4,
+		Ratio:    0.25,
+		Currency: "XXX",
+		Tags:     []string{"a", "c"},
+	}
+	v := Default()
+	err := v.Validate(&s)
+	assertNOK(t, err,
+		"Status must be one of [active suspended] (was deleted)",
+		"Role must be one of [admin user] (was Root)",
+		"Level must be one of [1 2 3] (was 4)",
+		"Ratio must be one of [0.5 1] (was 0.25)",
+		"Currency must not be one of [XXX]",
+		"Tags[1] must be one of [a b] (was c)",
+	)
+	s = s1{
+		Status: "active",
+		Role:   strPtr("ADMIN"),
+		Code:   -1,
+		Level:  3,
+		Ratio:  0.5,
+	}
+	err = v.Validate(&s)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOneOfCheck(t *testing.T) {
+	type s1 struct {
+		A string `valid:"oneof="`
+		B int    `valid:"oneof=1 a"`
+		C int    `valid:"oneofci=1 2"`
+		D bool   `valid:"oneof=true"`
+	}
+	err := Default().Check(s1{})
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	errs := err.(Errors)
+	if len(errs) != 4 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if e, ok := errs[0].(*TagError).Err.(*ParamError); !ok || e.Kind != "values" {
+		t.Fatalf("unexpected error: %+v", errs[0])
+	}
+}
+
+func TestOneOfFloat32(t *testing.T) {
+	s := struct {
+		F float32  `valid:"oneof=0.1 0.2"`
+		G float64  `valid:"oneof=0.1 0.2"`
+		H *float32 `valid:"oneof=0.3"`
+	}{
+		F: 0.1,
+		G: 0.2,
+	}
+	v := Default()
+	if err := v.Validate(&s); err != nil {
+		t.Fatal(err)
+	}
+	s.F = 0.3
+	err := v.Validate(&s)
+	assertNOK(t, err, "F must be one of [0.1 0.2] (was 0.30000001192092896)")
+}
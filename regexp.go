@@ -0,0 +1,61 @@
+package validator
+
+import (
+	"reflect"
+	"regexp"
+)
+
+// regexpMatch returns a function which validates if a string or []byte
+// matches the pattern in param.
+func (a *Validator) regexpMatch(t reflect.Type, param string) (Func, error) {
+	t = indirectType(t)
+	if t.Kind() != reflect.String && !isBytes(t) {
+		return nil, errUnsupported
+	}
+	re, err := a.compileRegexp(param)
+	if err != nil {
+		return nil, err
+	}
+	if t.Kind() == reflect.String {
+		return func(v reflect.Value, name, param string) error {
+			v, ok := indirect(v)
+			if !ok {
+				return errSkip
+			}
+			if !re.MatchString(v.String()) {
+				return newFieldError(v, name, param, "must match %s", param)
+			}
+			return nil
+		}, nil
+	}
+	return func(v reflect.Value, name, param string) error {
+		v, ok := indirect(v)
+		if !ok {
+			return errSkip
+		}
+		if !re.Match(v.Bytes()) {
+			return newFieldError(v, name, param, "must match %s", param)
+		}
+		return nil
+	}, nil
+}
+
+// compileRegexp returns the compiled regular expression of pattern s,
+// which is cached in the validator.
+func (a *Validator) compileRegexp(s string) (*regexp.Regexp, error) {
+	re, ok := a.regexpCache.get(s)
+	if ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(s)
+	if err != nil {
+		return nil, err
+	}
+	a.regexpCache.save(s, re)
+	return re, nil
+}
+
+// isBytes returns true if t is a slice of bytes.
+func isBytes(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+}
@@ -0,0 +1,57 @@
+package validator
+
+import "testing"
+
+func TestRegexp(t *testing.T) {
+	type s1 struct {
+		A string   `valid:"regexp='^\\d{1,3}$'"`
+		B *string  `valid:"regexp=^[a-z]+$"`
+		C []byte   `valid:"regexp=^[a-z]+$"`
+		D string   `valid:"!regexp=^admin"`
+		E []string `valid:"dive,regexp='^[a-z]{2,}$'"`
+	}
+	s := s1{
+		A: "1234",
+		B: strPtr("abc"),
+		C: []byte("abc1"),
+		D: "administrator",
+		E: []string{"ab", "c"},
+	}
+	v := Default()
+	err := v.Validate(&s)
+	assertNOK(t, err,
+		`A must match ^\d{1,3}$`,
+		"C must match ^[a-z]+$",
+		"D must not match ^admin",
+		"E[1] must match ^[a-z]{2,}$",
+	)
+	s = s1{
+		A: "123",
+		C: []byte("abc"),
+	}
+	err = v.Validate(&s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := v.regexpCache.get("^[a-z]+$"); !ok {
+		t.Fatal("pattern is not cached")
+	}
+}
+
+func TestRegexpCheck(t *testing.T) {
+	type s1 struct {
+		A string `valid:"regexp=[a-"`
+		B int    `valid:"regexp=^1$"`
+	}
+	err := Default().Check(s1{})
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	errs := err.(Errors)
+	if len(errs) != 2 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if errs[0].Error() != "rule \"regexp=[a-\" of validator.s1.A: error parsing regexp: missing closing ]: `[a-`" {
+		t.Fatalf("unexpected error: %v", errs[0])
+	}
+}
@@ -0,0 +1,445 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// builder returns a validation function for values of type t with
+// parameter param. It returns errUnsupported if type t is not supported.
+type builder func(t reflect.Type, param string) (Func, error)
+
+var errUnsupported = errors.New("validator: unsupported type")
+
+// errSkip is returned by built-in functions which do not validate a value,
+// e.g. a nil pointer or a string which cannot be compared. The rule neither
+// passes nor fails, so it is also skipped when negated.
+var errSkip = errors.New("validator: value skipped")
+
+const (
+	// omitEmptyTag skips validating the rules after it when the value
+	// is empty.
+	omitEmptyTag = "omitempty"
+	// diveTag applies the rules after it to each element of a slice,
+	// array or map. Rules for map keys are put between keysTag and
+	// endKeysTag right after diveTag.
+	diveTag    = "dive"
+	keysTag    = "keys"
+	endKeysTag = "endkeys"
+	// orSeparator separates alternative rules, e.g. "uuid|numeric".
+	orSeparator = '|'
+	// notPrefix negates a rule, e.g. "!notempty". Rules can also be
+	// negated with notFuncPrefix and notFuncSuffix, e.g. "not(min=3|max=1)".
+	notPrefix     = "!"
+	notFuncPrefix = "not("
+	notFuncSuffix = ")"
+)
+
+// structBuilder returns a validation function for field f of struct type st
+// with parameter param. The returned function is given the struct value
+// instead of the field value so it can access other fields.
+type structBuilder func(a *Validator, st reflect.Type, f reflect.StructField, param string) (Func, error)
+
+// handler resolves validation functions registered with a name.
+// Only one of its fields is set.
+type handler struct {
+	build       builder
+	buildStruct structBuilder
+	ctxFn       FuncContext
+
+	negMsg   string // message format when the negated rule fails
+	negBound *bound // messages of the negated rule by value class if set
+}
+
+// funcBuilder returns a builder which always uses function fn.
+func funcBuilder(fn Func) builder {
+	return func(t reflect.Type, param string) (Func, error) {
+		return fn, nil
+	}
+}
+
+// rule is a validation function resolved for a field type.
+type rule struct {
+	name  string
+	param string
+	fn    Func
+	ctxFn FuncContext // used instead of fn when set
+	err   error       // *TagError when building the rule
+
+	onStruct  bool // fn validates the struct containing the field
+	omitEmpty bool // skip next rules when the value is empty
+
+	dive bool   // validate elements instead of calling fn
+	keys []rule // rules for map keys when dive is set
+	elem []rule // rules for elements when dive is set
+
+	alts []rule // alternatives, one of which must pass, instead of fn
+
+	negate bool   // the rule must not pass
+	negMsg string // message format when the negated rule fails
+}
+
+// compileRules parses tags and resolves validation functions for field f
+// of struct type st.
+func (a *Validator) compileRules(st reflect.Type, f reflect.StructField, tags string) []rule {
+	var list []string
+	for _, tag := range splitTags(tags) {
+		expanded, err := a.expandAlias(tag, nil)
+		if err != nil {
+			r := rule{name: tag}
+			r.setError(st, f, err)
+			return []rule{r}
+		}
+		list = append(list, expanded...)
+	}
+	return a.compileTags(st, f, f.Type, list)
+}
+
+// expandAlias returns tags of alias tag, or the tag itself if it is not
+// an alias. Names of the aliases being expanded are given in seen.
+func (a *Validator) expandAlias(tag string, seen []string) ([]string, error) {
+	tags, ok := a.aliases[tag]
+	if !ok {
+		return []string{tag}, nil
+	}
+	seen = append(seen, tag)
+	for _, s := range seen[:len(seen)-1] {
+		if s == tag {
+			return nil, errors.New("alias cycle " + strings.Join(seen, " -> "))
+		}
+	}
+	var list []string
+	for _, t := range splitTags(tags) {
+		expanded, err := a.expandAlias(t, seen)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, expanded...)
+	}
+	return list, nil
+}
+
+// compileTags resolves validation functions in tags for values of type t,
+// which is either type of field f or its elements.
+func (a *Validator) compileTags(st reflect.Type, f reflect.StructField, t reflect.Type, tags []string) []rule {
+	var rules []rule
+	for i, tag := range tags {
+		if inner, ok := trimNegation(tag); ok {
+			r := a.compileAlternatives(st, f, t, inner)
+			switch {
+			case len(r.alts) > 0:
+				r.name = notFuncPrefix + r.name + notFuncSuffix
+			case r.negate:
+				// Double negation
+				r.name = r.name[len(notPrefix):]
+			default:
+				r.name = notPrefix + r.name
+			}
+			r.negate = !r.negate
+			rules = append(rules, r)
+			continue
+		}
+		if indexUnquoted(tag, orSeparator) >= 0 {
+			rules = append(rules, a.compileAlternatives(st, f, t, tag))
+			continue
+		}
+		switch tag {
+		case omitEmptyTag:
+			rules = append(rules, rule{name: tag, omitEmpty: true})
+			continue
+		case diveTag:
+			// All the remaining rules are for the elements.
+			return append(rules, a.compileDive(st, f, t, tags[i+1:]))
+		}
+		rules = append(rules, a.compileNegatable(st, f, t, tag))
+	}
+	return rules
+}
+
+// compileAlternatives resolves validation functions separated by
+// orSeparator in tag. The returned rule has only one alternative if
+// there is no orSeparator.
+func (a *Validator) compileAlternatives(st reflect.Type, f reflect.StructField, t reflect.Type, tag string) rule {
+	alts := splitAlternatives(tag)
+	if len(alts) == 1 {
+		return a.compileAlternative(st, f, t, tag)
+	}
+	r := rule{
+		name: tag,
+	}
+	for _, alt := range alts {
+		r.alts = append(r.alts, a.compileAlternative(st, f, t, alt))
+	}
+	return r
+}
+
+// compileAlternative resolves validation function in alternative tag,
+// which can be negated by notPrefix or wrapped by notFuncPrefix and
+// notFuncSuffix, e.g. "not(min=3)|not(max=1)".
+func (a *Validator) compileAlternative(st reflect.Type, f reflect.StructField, t reflect.Type, tag string) rule {
+	inner, ok := trimNegation(tag)
+	switch {
+	case !ok:
+		return a.compileNegatable(st, f, t, tag)
+	case len(splitAlternatives(inner)) > 1:
+		r := rule{name: tag}
+		r.setError(st, f, errors.New("nested alternatives"))
+		return r
+	case strings.HasPrefix(inner, notPrefix):
+		// Double negation
+		return a.compileNegatable(st, f, t, inner[len(notPrefix):])
+	}
+	return a.compileNegatable(st, f, t, notPrefix+inner)
+}
+
+// compileNegatable resolves validation function in tag which can be
+// prefixed by notPrefix. Aliases are expanded before, so tag is only an
+// alias if it is negated or an alternative, which is not supported.
+func (a *Validator) compileNegatable(st reflect.Type, f reflect.StructField, t reflect.Type, tag string) rule {
+	negate := strings.HasPrefix(tag, notPrefix)
+	if negate {
+		tag = tag[len(notPrefix):]
+	}
+	name, param, err := parseTag(tag)
+	if _, ok := a.aliases[tag]; ok {
+		err = errors.New("alias " + tag + " cannot be negated or used in alternatives")
+	}
+	var r rule
+	if err != nil {
+		r = rule{name: name, param: param}
+		r.setError(st, f, err)
+	} else {
+		r = a.compileRule(st, f, t, name, param)
+	}
+	if negate {
+		r.negate = true
+		r.name = notPrefix + r.name
+	}
+	return r
+}
+
+// negatedMessage returns the error message when the validation function of
+// negated rule r passes.
+func (r *rule) negatedMessage() string {
+	if len(r.alts) > 0 {
+		// None of the alternatives must pass.
+		msgs := make([]string, len(r.alts))
+		for i := range r.alts {
+			alt := &r.alts[i]
+			if alt.negate {
+				msgs[i] = "must satisfy " + alt.tag()
+			} else {
+				msgs[i] = alt.negatedMessage()
+			}
+		}
+		return strings.Join(msgs, " and ")
+	}
+	if r.negMsg == "" {
+		return "must not satisfy " + r.tag()
+	}
+	switch n := strings.Count(r.negMsg, "%s"); {
+	case n == 1:
+		return fmt.Sprintf(r.negMsg, r.param)
+	case n > 1:
+		// Parameters separated by spaces, e.g. bounds of 'between'.
+		fields := strings.Fields(r.param)
+		args := make([]interface{}, len(fields))
+		for i, s := range fields {
+			args[i] = s
+		}
+		return fmt.Sprintf(r.negMsg, args...)
+	}
+	return r.negMsg
+}
+
+// tag returns the function name and parameter of rule r without negation.
+func (r *rule) tag() string {
+	tag := strings.TrimPrefix(r.name, notPrefix)
+	if r.param != "" {
+		tag += "=" + r.param
+	}
+	return tag
+}
+
+// compileDive resolves validation functions in tags for elements of t.
+func (a *Validator) compileDive(st reflect.Type, f reflect.StructField, t reflect.Type, tags []string) rule {
+	r := rule{
+		name: diveTag,
+		dive: true,
+	}
+	et := indirectType(t)
+	switch et.Kind() {
+	case reflect.Slice, reflect.Array:
+		r.elem = a.compileTags(st, f, et.Elem(), tags)
+	case reflect.Map:
+		if len(tags) > 0 && tags[0] == keysTag {
+			end := -1
+			for i, tag := range tags {
+				if tag == endKeysTag {
+					end = i
+					break
+				}
+			}
+			if end < 0 {
+				r.setError(st, f, errors.New("missing "+endKeysTag))
+				return r
+			}
+			r.keys = a.compileTags(st, f, et.Key(), tags[1:end])
+			tags = tags[end+1:]
+		}
+		r.elem = a.compileTags(st, f, et.Elem(), tags)
+	default:
+		r.setError(st, f, UnsupportedError(t.String()))
+		r.fn = unsupported
+	}
+	return r
+}
+
+// compileRule resolves validation function name for values of type t in
+// field f of struct type st. When the rule is invalid, its function reports
+// the error at validation time.
+func (a *Validator) compileRule(st reflect.Type, f reflect.StructField, t reflect.Type, name, param string) rule {
+	r := rule{
+		name:  name,
+		param: param,
+	}
+	h, ok := a.funcs[name]
+	if !ok {
+		r.fn = errorFunc(UnsupportedError(name))
+		r.setError(st, f, UnsupportedError(name))
+		return r
+	}
+	r.negMsg = h.negMsg
+	if h.negBound != nil {
+		r.negMsg = h.negBound.message(a.classOf(t, param))
+	}
+	if h.ctxFn != nil {
+		r.ctxFn = h.ctxFn
+		return r
+	}
+	var fn Func
+	var err error
+	if h.buildStruct != nil {
+		// Struct functions can only validate the field itself.
+		if t == f.Type {
+			r.onStruct = true
+			fn, err = h.buildStruct(a, st, f, param)
+		} else {
+			err = errUnsupported
+		}
+	} else {
+		fn, err = h.build(t, param)
+	}
+	switch err {
+	case nil:
+		r.fn = fn
+	case errUnsupported:
+		r.fn = unsupported
+		r.setError(st, f, UnsupportedError(t.String()))
+	default:
+		r.setError(st, f, err)
+	}
+	return r
+}
+
+// setError sets the rule error as a *TagError for field f of struct
+// type st. If the rule does not have a function, the error is reported
+// at validation time.
+func (r *rule) setError(st reflect.Type, f reflect.StructField, err error) {
+	r.err = &TagError{
+		Type:  st,
+		Field: f.Name,
+		Rule:  r.name,
+		Param: r.param,
+		Err:   err,
+	}
+	if r.fn == nil {
+		r.fn = errorFunc(r.err)
+	}
+}
+
+// ruleErrors appends errors of the rules and their element rules to errs.
+func ruleErrors(errs []error, rules []rule) []error {
+	for i := range rules {
+		r := &rules[i]
+		if r.err != nil {
+			errs = append(errs, r.err)
+		}
+		errs = ruleErrors(errs, r.keys)
+		errs = ruleErrors(errs, r.elem)
+		errs = ruleErrors(errs, r.alts)
+	}
+	return errs
+}
+
+// unsupported is a validation function for types which are not supported.
+func unsupported(v reflect.Value, name, param string) error {
+	return UnsupportedError(name)
+}
+
+// errorFunc returns a validation function which always returns err.
+func errorFunc(err error) Func {
+	return func(v reflect.Value, name, param string) error {
+		return err
+	}
+}
+
+// indirectType returns the type t points to.
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// indirect returns the value v points to or false if v is a nil pointer.
+func indirect(v reflect.Value) (reflect.Value, bool) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return v, false
+		}
+		v = v.Elem()
+	}
+	return v, true
+}
+
+// isEmpty returns true if v is the zero value of its type, a nil pointer
+// or an empty string, slice or map.
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return v.IsZero()
+}
+
+func parseInt(s string) (int64, error) {
+	n, err := strconv.ParseInt(s, 0, 64)
+	return n, numberError(s, err)
+}
+
+func parseUint(s string) (uint64, error) {
+	n, err := strconv.ParseUint(s, 0, 64)
+	return n, numberError(s, err)
+}
+
+func parseFloat(s string) (float64, error) {
+	n, err := strconv.ParseFloat(s, 64)
+	return n, numberError(s, err)
+}
+
+// numberError converts err from parsing number s to a *ParamError.
+func numberError(s string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if e, ok := err.(*strconv.NumError); ok {
+		err = e.Err
+	}
+	return &ParamError{Param: s, Kind: "number", Err: err}
+}
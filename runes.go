@@ -0,0 +1,58 @@
+package validator
+
+import (
+	"reflect"
+	"unicode/utf8"
+)
+
+var (
+	minRunesBound = bound{
+		ok:     func(c int) bool { return c >= 0 },
+		lenMsg: "must have length not less than %s runes",
+	}
+	maxRunesBound = bound{
+		ok:     func(c int) bool { return c <= 0 },
+		lenMsg: "must have length not greater than %s runes",
+	}
+)
+
+func minRunes(t reflect.Type, param string) (Func, error) {
+	return buildRunes(t, param, &minRunesBound)
+}
+
+func maxRunes(t reflect.Type, param string) (Func, error) {
+	return buildRunes(t, param, &maxRunesBound)
+}
+
+// buildRunes returns a function which validates the number of runes in
+// UTF-8 encoded strings or []byte of type t with bound b and parameter param.
+func buildRunes(t reflect.Type, param string, b *bound) (Func, error) {
+	t = indirectType(t)
+	if t.Kind() != reflect.String && !isBytes(t) {
+		return nil, errUnsupported
+	}
+	n, err := parseInt(param)
+	if err != nil {
+		return nil, err
+	}
+	msg := b.lenMsg + " (was %d)"
+	return func(v reflect.Value, name, param string) error {
+		v, ok := indirect(v)
+		if !ok {
+			return errSkip
+		}
+		count := runeCount(v)
+		if !b.ok(compareInt(int64(count), n)) {
+			return newFieldError(v, name, param, msg, param, count)
+		}
+		return nil
+	}, nil
+}
+
+// runeCount returns the number of runes in string or []byte v.
+func runeCount(v reflect.Value) int {
+	if v.Kind() == reflect.String {
+		return utf8.RuneCountInString(v.String())
+	}
+	return utf8.RuneCount(v.Bytes())
+}
@@ -0,0 +1,61 @@
+package validator
+
+import "testing"
+
+func TestMinMaxRunes(t *testing.T) {
+	s := struct {
+		A string  `valid:"minrunes=3"`
+		B string  `valid:"minrunes=3"`
+		C []byte  `valid:"maxrunes=2"`
+		D string  `valid:"maxrunes=2"`
+		E *string `valid:"minrunes=1"`
+		F string  `valid:"maxrunes=3"`
+	}{
+		A: "Zoë",
+		B: "日本",
+		C: []byte("Việt"),
+		D: "ab",
+		F: "日本語",
+	}
+	v := New(withBuilder("minrunes", minRunes), withBuilder("maxrunes", maxRunes))
+	err := v.Validate(&s)
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	errs := err.(Errors)
+	want := []string{
+		"B must have length not less than 3 runes (was 2)",
+		"C must have length not greater than 2 runes (was 4)",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("unexpected errors length: %+v; want: %d", errs, len(want))
+	}
+	for i := range want {
+		if errs[i].Error() != want[i] {
+			t.Fatalf("unexpected error: %+v; want: %s", errs[i], want[i])
+		}
+	}
+}
+
+func TestRunesUnsupported(t *testing.T) {
+	s := struct {
+		A int    `valid:"minrunes=1"`
+		B []int  `valid:"maxrunes=1"`
+		C string `valid:"minrunes=a"`
+	}{}
+	v := New(withBuilder("minrunes", minRunes), withBuilder("maxrunes", maxRunes))
+	err := v.Check(&s)
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	errs := err.(Errors)
+	if len(errs) != 3 {
+		t.Fatalf("unexpected errors length: %+v; want: %d", errs, 3)
+	}
+	if errs[0].(*TagError).Err != UnsupportedError("int") {
+		t.Fatalf("unexpected error: %+v", errs[0])
+	}
+	if errs[1].(*TagError).Err != UnsupportedError("[]int") {
+		t.Fatalf("unexpected error: %+v", errs[1])
+	}
+}
@@ -0,0 +1,191 @@
+package validator
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+)
+
+// Rules in a tag are separated by comma, e.g. "notempty,max=10". Parameters
+// containing special characters can be quoted with single quotes, e.g.
+// "regexp='^\d{1,3}$'", or escaped with backslash, e.g. "oneof=a\,b".
+// Inside quotes, only single quote and backslash can be escaped. Quotes
+// elsewhere than the beginning of a parameter are kept, e.g. "oneof=don't".
+const (
+	tagSeparator = ','
+	quote        = '\''
+	escape       = '\\'
+)
+
+var errUnterminatedQuote = errors.New("unterminated quote")
+
+// splitTags returns rules separated by comma in tags.
+func splitTags(tags string) []string {
+	if tags == "" {
+		return nil
+	}
+	list := splitUnquoted(tags, tagSeparator)
+	// Allow trailing separator
+	if list[len(list)-1] == "" {
+		list = list[:len(list)-1]
+	}
+	return list
+}
+
+// splitUnquoted splits s by separator sep which is neither quoted nor
+// escaped. Quotes and escapes are kept in the result.
+func splitUnquoted(s string, sep byte) []string {
+	var list []string
+	for {
+		i := indexUnquoted(s, sep)
+		if i < 0 {
+			return append(list, s)
+		}
+		list = append(list, s[:i])
+		s = s[i+1:]
+	}
+}
+
+// splitAlternatives splits tag by orSeparator which is neither quoted,
+// escaped nor inside parentheses, e.g. "not(a|b)|c" has two alternatives.
+func splitAlternatives(tag string) []string {
+	var list []string
+	depth, start := 0, 0
+	scanUnquoted(tag, func(i int) bool {
+		switch tag[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case orSeparator:
+			if depth <= 0 {
+				list = append(list, tag[start:i])
+				start = i + 1
+			}
+		}
+		return true
+	})
+	return append(list, tag[start:])
+}
+
+// indexUnquoted returns the index of the first sep in s which is neither
+// quoted nor escaped, or -1 if there is none.
+func indexUnquoted(s string, sep byte) int {
+	index := -1
+	scanUnquoted(s, func(i int) bool {
+		if s[i] == sep {
+			index = i
+			return false
+		}
+		return true
+	})
+	return index
+}
+
+// scanUnquoted calls fn with the index of each character in s which is
+// neither quoted nor escaped, until fn returns false. Quotes only start at
+// the beginning of a rule, an alternative or a parameter.
+func scanUnquoted(s string, fn func(i int) bool) {
+	quoted := false
+	start := true    // at the beginning of a rule, alternative or parameter
+	inParam := false // after the '=' of the current rule
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == escape && i+1 < len(s) && escapable(s[i+1], quoted):
+			i++
+			start = false
+		case quoted:
+			quoted = c != quote
+		case c == quote && start:
+			quoted = true
+			start = false
+		default:
+			start = false
+			switch {
+			case c == tagSeparator || c == orSeparator:
+				start, inParam = true, false
+			case c == '=' && !inParam:
+				start, inParam = true, true
+			}
+			if !fn(i) {
+				return
+			}
+		}
+	}
+}
+
+// unquote removes escapes in parameter s, and quotes if s starts with one.
+func unquote(s string) (string, error) {
+	if strings.IndexByte(s, quote) < 0 && strings.IndexByte(s, escape) < 0 {
+		return s, nil
+	}
+	var buf bytes.Buffer
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == escape && i+1 < len(s) && escapable(s[i+1], quoted):
+			i++
+			buf.WriteByte(s[i])
+		case c == quote && (i == 0 || quoted):
+			quoted = !quoted
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	if quoted {
+		return "", errUnterminatedQuote
+	}
+	return buf.String(), nil
+}
+
+// escapable returns true if c can be escaped. Backslashes before other
+// characters are kept so patterns like \d do not need to be escaped.
+func escapable(c byte, quoted bool) bool {
+	switch c {
+	case quote, escape:
+		return true
+	case tagSeparator, orSeparator:
+		return !quoted
+	}
+	return false
+}
+
+// parseTag returns function name and unquoted parameter.
+func parseTag(tag string) (name, param string, err error) {
+	i := strings.IndexByte(tag, '=')
+	if i < 0 {
+		return tag, "", nil
+	}
+	name = tag[:i]
+	param, err = unquote(tag[i+1:])
+	if err != nil {
+		param = tag[i+1:]
+	}
+	return
+}
+
+// trimNegation returns tag without notFuncPrefix and notFuncSuffix if
+// the tag is wrapped by them, i.e. the parenthesis opened by notFuncPrefix
+// is closed by notFuncSuffix at the end, unlike "not(a)|not(b)".
+func trimNegation(tag string) (string, bool) {
+	if !strings.HasPrefix(tag, notFuncPrefix) || !strings.HasSuffix(tag, notFuncSuffix) {
+		return tag, false
+	}
+	inner := tag[len(notFuncPrefix) : len(tag)-len(notFuncSuffix)]
+	depth := 0
+	scanUnquoted(inner, func(i int) bool {
+		switch inner[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		}
+		return depth >= 0
+	})
+	if depth < 0 {
+		return tag, false
+	}
+	return inner, true
+}
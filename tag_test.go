@@ -0,0 +1,102 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestSplitTags(t *testing.T) {
+	tests := []struct {
+		tags     string
+		expected []string
+	}{
+		{"", nil},
+		{"a", []string{"a"}},
+		{"a,b=1,", []string{"a", "b=1"}},
+		{"a,,b", []string{"a", "", "b"}},
+		{`a='1,2',b`, []string{`a='1,2'`, "b"}},
+		{`a=1\,2,b`, []string{`a=1\,2`, "b"}},
+		{`a='it\'s,ok',b`, []string{`a='it\'s,ok'`, "b"}},
+		{`a='\d{1,3}'|b`, []string{`a='\d{1,3}'|b`}},
+		{`a='1,2`, []string{`a='1,2`}},
+		{`a=don't,b`, []string{`a=don't`, "b"}},
+		{`a=x'|b='y,z'`, []string{`a=x'|b='y,z'`}},
+	}
+	for _, tt := range tests {
+		list := splitTags(tt.tags)
+		if !reflect.DeepEqual(list, tt.expected) {
+			t.Errorf("unexpected tags of %q: %q; want: %q", tt.tags, list, tt.expected)
+		}
+	}
+}
+
+func TestParseTag(t *testing.T) {
+	tests := []struct {
+		tag   string
+		name  string
+		param string
+		err   error
+	}{
+		{"a", "a", "", nil},
+		{"a=", "a", "", nil},
+		{"a=b=c", "a", "b=c", nil},
+		{`a='^\d{1,3}$'`, "a", `^\d{1,3}$`, nil},
+		{`a=x\,y\|z\\`, "a", `x,y|z\`, nil},
+		{`a='x\,y\|z\'\\'`, "a", `x\,y\|z'\`, nil},
+		{`a='x' y 'z'`, "a", `x y 'z'`, nil},
+		{`a=don't`, "a", `don't`, nil},
+		{`a=don\'t`, "a", `don't`, nil},
+		{`a='x`, "a", `'x`, errUnterminatedQuote},
+	}
+	for _, tt := range tests {
+		name, param, err := parseTag(tt.tag)
+		if name != tt.name || param != tt.param || err != tt.err {
+			t.Errorf("unexpected result of %q: %q %q %v; want: %q %q %v",
+				tt.tag, name, param, err, tt.name, tt.param, tt.err)
+		}
+	}
+}
+
+func TestQuotedParam(t *testing.T) {
+	type s1 struct {
+		A string `valid:"nok='a,b',nok=c\\,d"`
+		B string `valid:"nok='x|y'|nok=z"`
+		C string `valid:"nok='abc,min=1"`
+		D string `valid:"nok=don't|nok"`
+	}
+	var params []string
+	v := New(WithFunc("nok", func(rv reflect.Value, name, param string) error {
+		params = append(params, param)
+		return nil
+	}))
+	err := v.Validate(&s1{})
+	assertNOK(t, err, `rule "nok='abc,min=1" of validator.s1.C: unterminated quote`)
+	expected := []string{"a,b", "c,d", "x|y", "don't"}
+	if fmt.Sprint(params) != fmt.Sprint(expected) {
+		t.Fatalf("unexpected params: %q; want: %q", params, expected)
+	}
+}
+
+func TestTrimNegation(t *testing.T) {
+	tests := []struct {
+		tag     string
+		inner   string
+		negated bool
+	}{
+		{"not(a)", "a", true},
+		{"not(a|b=1)", "a|b=1", true},
+		{"not(not(a)|b)", "not(a)|b", true},
+		{"not(a)|not(b)", "not(a)|not(b)", false},
+		{"not(a)|b=1)", "not(a)|b=1)", false},
+		{"not(a=')(')", "a=')('", true},
+		{"a)", "a)", false},
+	}
+	for _, tt := range tests {
+		inner, negated := trimNegation(tt.tag)
+		if inner != tt.inner || negated != tt.negated {
+			t.Errorf("unexpected result of %q: %q %v; want: %q %v",
+				tt.tag, inner, negated, tt.inner, tt.negated)
+		}
+	}
+}
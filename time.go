@@ -0,0 +1,138 @@
+package validator
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// dateLayout is the layout of dates in ISO 8601.
+const dateLayout = "2006-01-02"
+
+// timeLayouts are layouts of time parameters.
+var timeLayouts = []string{time.RFC3339, dateLayout}
+
+// nowParam is the current time in time parameters. It can be followed by
+// an offset, e.g. "now-24h".
+const nowParam = "now"
+
+func (a *Validator) past(t reflect.Type, param string) (Func, error) {
+	return a.buildTime(t, func(c int) bool { return c < 0 }, "must be in the past")
+}
+
+func (a *Validator) future(t reflect.Type, param string) (Func, error) {
+	return a.buildTime(t, func(c int) bool { return c > 0 }, "must be in the future")
+}
+
+// datetime returns a function validating if strings or []byte can be parsed
+// as time with the layout in param, e.g. "2006-01-02 15:04".
+func datetime(t reflect.Type, param string) (Func, error) {
+	if param == "" {
+		return nil, &ParamError{Param: param, Kind: "layout"}
+	}
+	return buildDatetime(t, param, "must be a valid date and time in format "+param)
+}
+
+func rfc3339(t reflect.Type, param string) (Func, error) {
+	return buildDatetime(t, time.RFC3339, "must be a valid RFC 3339 date and time")
+}
+
+func date(t reflect.Type, param string) (Func, error) {
+	return buildDatetime(t, dateLayout, "must be a valid date in format "+dateLayout)
+}
+
+func buildDatetime(t reflect.Type, layout, msg string) (Func, error) {
+	return textFormat(t, func(s string) bool {
+		_, err := time.Parse(layout, s)
+		return err == nil
+	}, msg)
+}
+
+// buildTime returns a function which validates if ok returns true for the
+// result of comparing time.Time values with the current time.
+func (a *Validator) buildTime(t reflect.Type, ok func(int) bool, msg string) (Func, error) {
+	if indirectType(t) != timeType {
+		return nil, errUnsupported
+	}
+	return formatFunc(func(v reflect.Value) bool {
+		return ok(compareTime(v.Interface().(time.Time), a.now()))
+	}, msg), nil
+}
+
+// parseTime parses s in one of timeLayouts or as the current time with
+// an optional offset, e.g. "now+1h". The returned function gives the time
+// when it is called. The layout is empty if the time is relative.
+func (a *Validator) parseTime(s string) (tm func() time.Time, layout string, err error) {
+	if strings.HasPrefix(s, nowParam) {
+		var offset time.Duration
+		if s != nowParam {
+			sign := s[len(nowParam)]
+			if sign != '+' && sign != '-' {
+				return nil, "", &ParamError{Param: s, Kind: "time"}
+			}
+			offset, err = parseDuration(s[len(nowParam):])
+			if err != nil {
+				return nil, "", err
+			}
+		}
+		return func() time.Time {
+			return a.now().Add(offset)
+		}, "", nil
+	}
+	for _, layout := range timeLayouts {
+		t, err := time.Parse(layout, s)
+		if err == nil {
+			return func() time.Time {
+				return t
+			}, layout, nil
+		}
+	}
+	return nil, "", &ParamError{Param: s, Kind: "time"}
+}
+
+// timeComparator returns a function comparing time in strings with time
+// parameter s. Strings are parsed with the layout of s, or any of
+// timeLayouts if s is relative. Strings which cannot be parsed are not
+// compared.
+func (a *Validator) timeComparator(s string) (func(reflect.Value) (int, bool), error) {
+	tm, layout, err := a.parseTime(s)
+	if err != nil {
+		return nil, err
+	}
+	layouts := timeLayouts
+	if layout != "" {
+		layouts = []string{layout}
+	}
+	return func(v reflect.Value) (int, bool) {
+		for _, layout := range layouts {
+			t, err := time.Parse(layout, v.String())
+			if err == nil {
+				return compareTime(t, tm()), true
+			}
+		}
+		return 0, false
+	}, nil
+}
+
+func parseDuration(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, &ParamError{Param: s, Kind: "duration"}
+	}
+	return d, nil
+}
+
+func compareTime(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+	return 0
+}
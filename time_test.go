@@ -0,0 +1,200 @@
+package validator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDurationBound(t *testing.T) {
+	s := struct {
+		A time.Duration  `valid:"min=1s"`
+		B time.Duration  `valid:"max=30s"`
+		C *time.Duration `valid:"between=1ms 1s"`
+		D time.Duration  `valid:"gt=0s"`
+		E time.Duration  `valid:"max=1000"`
+	}{
+		A: 500 * time.Millisecond,
+		B: 30 * time.Second,
+		C: new(time.Duration),
+		D: time.Nanosecond,
+		E: 2 * time.Microsecond,
+	}
+	v := Default()
+	err := v.Validate(&s)
+	assertNOK(t, err,
+		"A must not be less than 1s (was 500ms)",
+		"C must be between 1ms and 1s (was 0s)",
+		"E must not be greater than 1000 (was 2µs)",
+	)
+}
+
+func TestTimeBound(t *testing.T) {
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	s := struct {
+		A time.Time  `valid:"min=2020-01-01T00:00:00Z"`
+		B time.Time  `valid:"max=now"`
+		C time.Time  `valid:"gt=now-24h"`
+		D *time.Time `valid:"lt=now+1h"`
+		E time.Time  `valid:"between=2020-01-01T00:00:00Z now"`
+		F time.Time  `valid:"min=now-1h"`
+	}{
+		A: time.Date(2019, 12, 31, 0, 0, 0, 0, time.UTC),
+		B: now,
+		C: now.Add(-24 * time.Hour),
+		E: now.Add(time.Second),
+		F: now.Add(-time.Hour),
+	}
+	v := New(DefaultOption(), WithClock(func() time.Time {
+		return now
+	}))
+	err := v.Validate(&s)
+	assertNOK(t, err,
+		"A must not be before 2020-01-01T00:00:00Z (was 2019-12-31 00:00:00 +0000 UTC)",
+		"C must be after now-24h (was 2020-05-31 12:00:00 +0000 UTC)",
+		"E must be between 2020-01-01T00:00:00Z and now (was 2020-06-01 12:00:01 +0000 UTC)",
+	)
+	// Relative bounds follow the clock.
+	now = now.Add(time.Minute)
+	err = v.Validate(&s)
+	assertNOK(t, err,
+		"A must not be before 2020-01-01T00:00:00Z (was 2019-12-31 00:00:00 +0000 UTC)",
+		"C must be after now-24h (was 2020-05-31 12:00:00 +0000 UTC)",
+		"F must not be before now-1h (was 2020-06-01 11:00:00 +0000 UTC)",
+	)
+}
+
+func TestTimeInvalidParam(t *testing.T) {
+	s := struct {
+		A time.Duration `valid:"min=1x"`
+		B time.Time     `valid:"max=01/02/2020"`
+		C time.Time     `valid:"min=now1h"`
+		D time.Time     `valid:"min=now+1d"`
+		E time.Time     `valid:"len=1"`
+	}{}
+	err := Default().Check(&s)
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	errs := err.(Errors)
+	if len(errs) != 5 {
+		t.Fatalf("unexpected errors length: %+v; want: %d", errs, 5)
+	}
+	if errs[1].(*TagError).Err.Error() != `invalid time "01/02/2020"` {
+		t.Fatalf("unexpected error: %+v", errs[1])
+	}
+	if errs[4].(*TagError).Err != UnsupportedError("time.Time") {
+		t.Fatalf("unexpected error: %+v", errs[4])
+	}
+}
+
+func TestPastFuture(t *testing.T) {
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	s := struct {
+		A time.Time  `valid:"past"`
+		B time.Time  `valid:"past"`
+		C *time.Time `valid:"future"`
+		D time.Time  `valid:"future"`
+		E time.Time  `valid:"omitempty,future"`
+	}{
+		A: now.Add(-time.Second),
+		B: now,
+		D: now.Add(time.Second),
+	}
+	v := New(DefaultOption(), WithClock(func() time.Time {
+		return now
+	}))
+	err := v.Validate(&s)
+	assertNOK(t, err, "B must be in the past")
+	now = now.Add(time.Second)
+	err = v.Validate(&s)
+	assertNOK(t, err, "D must be in the future")
+}
+
+func TestPastUnsupported(t *testing.T) {
+	s := struct {
+		A string `valid:"past"`
+	}{}
+	err := Default().Check(&s)
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	if errs := err.(Errors); errs[0].(*TagError).Err != UnsupportedError("string") {
+		t.Fatalf("unexpected error: %+v", errs[0])
+	}
+}
+
+func TestDatetime(t *testing.T) {
+	s := struct {
+		A string  `valid:"datetime=2006-01-02 15:04"`
+		B string  `valid:"datetime=2006-01-02 15:04"`
+		C []byte  `valid:"rfc3339"`
+		D *string `valid:"rfc3339"`
+		E string  `valid:"date"`
+		F string  `valid:"date"`
+	}{
+		A: "2020-06-01 12:00",
+		B: "2020-06-01T12:00",
+		C: []byte("2020-06-01T12:00:00+07:00"),
+		D: strPtr("2020-06-01"),
+		E: "2020-06-01",
+		F: "2020-02-30",
+	}
+	err := Default().Validate(&s)
+	assertNOK(t, err,
+		"B must be a valid date and time in format 2006-01-02 15:04",
+		"D must be a valid RFC 3339 date and time",
+		"F must be a valid date in format 2006-01-02",
+	)
+}
+
+func TestDatetimeBound(t *testing.T) {
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	s := struct {
+		A string `valid:"date,min=2000-01-01"`
+		B string `valid:"date,min=2000-01-01"`
+		C string `valid:"date,min=2000-01-01"`
+		D string `valid:"rfc3339,lt=now"`
+		E string `valid:"date,between=2020-01-01 now"`
+		F string `valid:"min=3"`
+	}{
+		A: "2000-01-01",
+		B: "1999-12-31",
+		C: "01/01/1999",
+		D: "2020-06-01T13:00:00+01:00",
+		E: "2020-06-02",
+		F: "ab",
+	}
+	v := New(DefaultOption(), WithClock(func() time.Time {
+		return now
+	}))
+	err := v.Validate(&s)
+	assertNOK(t, err,
+		"B must not be before 2000-01-01 (was 1999-12-31)",
+		"C must be a valid date in format 2006-01-02",
+		"D must be before now (was 2020-06-01T13:00:00+01:00)",
+		"E must be between 2020-01-01 and now (was 2020-06-02)",
+		"F must have length not less than 3 (was 2)",
+	)
+}
+
+func TestDatetimeInvalidParam(t *testing.T) {
+	s := struct {
+		A string `valid:"datetime"`
+		B int    `valid:"date"`
+		C string `valid:"between=2000-01-01 10"`
+	}{}
+	err := Default().Check(&s)
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	errs := err.(Errors)
+	if len(errs) != 3 {
+		t.Fatalf("unexpected errors length: %+v; want: %d", errs, 3)
+	}
+	if e, ok := errs[0].(*TagError).Err.(*ParamError); !ok || e.Kind != "layout" {
+		t.Fatalf("unexpected error: %+v", errs[0])
+	}
+	if e, ok := errs[2].(*TagError).Err.(*ParamError); !ok || e.Error() != `invalid bounds "2000-01-01 10"` {
+		t.Fatalf("unexpected error: %+v", errs[2])
+	}
+}
@@ -1,11 +1,13 @@
-// Package validator provides validation for structs and fields.
 package validator
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
+	"time"
 	"unicode"
 )
 
@@ -22,6 +24,113 @@ func (e UnsupportedError) Error() string {
 	return "validator: unsupported: " + string(e)
 }
 
+// TagError is returned when a validation tag of a struct field is invalid,
+// e.g. the function is unknown, the parameter is malformed or the function
+// does not support type of the field.
+type TagError struct {
+	// Type is the struct type.
+	Type reflect.Type
+	// Field is the Go name of the struct field.
+	Field string
+	// Rule is the name of the validation function.
+	Rule string
+	// Param is the parameter given to the validation function.
+	Param string
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *TagError) Error() string {
+	tag := e.Rule
+	if e.Param != "" {
+		tag += "=" + e.Param
+	}
+	return "rule " + strconv.Quote(tag) + " of " + e.Type.String() + "." + e.Field + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *TagError) Unwrap() error {
+	return e.Err
+}
+
+// ParamError is the underlying error of a TagError when the parameter of
+// a validation function is malformed or out of range.
+type ParamError struct {
+	// Param is the invalid parameter, or the invalid part of it.
+	Param string
+	// Kind describes the expected parameter, e.g. "number".
+	Kind string
+	// Err is the reason, e.g. strconv.ErrRange, or nil if not specified.
+	Err error
+}
+
+func (e *ParamError) Error() string {
+	msg := "invalid " + e.Kind + " " + strconv.Quote(e.Param)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the reason of the error.
+func (e *ParamError) Unwrap() error {
+	return e.Err
+}
+
+// FieldError is returned when a field fails a validation rule.
+type FieldError struct {
+	// Field is the name of the field.
+	Field string
+	// Path is the full path to the field from the validated value.
+	Path string
+	// Rule is the name of the validation function which failed.
+	Rule string
+	// Param is the parameter given to the validation function.
+	Param string
+	// Value is the value of the field.
+	Value interface{}
+	// Message describes the failure, without the field name.
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	name := e.Path
+	if name == "" {
+		name = e.Field
+	}
+	return name + " " + e.Message
+}
+
+// newFieldError returns a FieldError for field path name with value v
+// and given formatted message.
+func newFieldError(v reflect.Value, name, param string, format string, args ...interface{}) *FieldError {
+	e := &FieldError{
+		Path:    name,
+		Param:   param,
+		Message: fmt.Sprintf(format, args...),
+	}
+	if v.IsValid() && v.CanInterface() {
+		e.Value = v.Interface()
+	}
+	return e
+}
+
+// fill sets missing details of the error from the rule being validated.
+func (e *FieldError) fill(name, path, rule, param string) {
+	if e.Field == "" {
+		e.Field = name
+	}
+	if e.Path == "" {
+		e.Path = path
+	}
+	if e.Rule == "" {
+		e.Rule = rule
+	}
+	if e.Param == "" {
+		e.Param = param
+	}
+}
+
 // Errors is a list of error.
 type Errors []error
 
@@ -38,8 +147,14 @@ func (e Errors) Error() string {
 }
 
 // Func validates field with value v, field name and parameter p.
+// The name is the full path to the field from the validated value,
+// e.g. Addresses[1].PostCode.
 type Func func(v reflect.Value, name, param string) error
 
+// FuncContext is like Func but also receives the context given to
+// Validator.ValidateContext.
+type FuncContext func(ctx context.Context, v reflect.Value, name, param string) error
+
 // Option sets options for the validator.
 type Option func(v *Validator)
 
@@ -49,14 +164,27 @@ type Validatable interface {
 	Validate() error
 }
 
-var validatableType = reflect.TypeOf(new(Validatable)).Elem()
+// ValidatableContext is like Validatable but its Validate method receives
+// the context given to Validator.ValidateContext.
+type ValidatableContext interface {
+	Validate(ctx context.Context) error
+}
+
+var (
+	validatableType        = reflect.TypeOf(new(Validatable)).Elem()
+	validatableContextType = reflect.TypeOf(new(ValidatableContext)).Elem()
+)
 
 // Validator implements value validation for structs and fields.
 type Validator struct {
-	tagName string
-	funcs   map[string]Func
-
-	fieldCache fieldCache
+	tagName  string
+	funcs    map[string]handler
+	aliases  map[string]string
+	nameFunc func(reflect.StructField) string
+	now      func() time.Time
+
+	fieldCache  fieldCache
+	regexpCache regexpCache
 }
 
 // New allocates and returns a new Validator with given options.
@@ -64,7 +192,9 @@ type Validator struct {
 func New(options ...Option) *Validator {
 	v := &Validator{
 		tagName: defaultTagName,
-		funcs:   make(map[string]Func),
+		funcs:   make(map[string]handler),
+		aliases: make(map[string]string),
+		now:     time.Now,
 	}
 	for _, opt := range options {
 		opt(v)
@@ -79,6 +209,44 @@ func WithTagName(tagName string) Option {
 	}
 }
 
+// WithFieldNameFunc returns an Option which sets the function to resolve
+// field names used in errors. When fn returns an empty string, the Go
+// field name is used.
+func WithFieldNameFunc(fn func(reflect.StructField) string) Option {
+	return func(v *Validator) {
+		v.nameFunc = fn
+	}
+}
+
+// WithNameTag returns an Option which uses field names from the given
+// struct tag, e.g. "json", in errors. Tag options such as ",omitempty"
+// are ignored and fields without a name or with name "-" are reported
+// with their Go field name.
+func WithNameTag(tagName string) Option {
+	return WithFieldNameFunc(func(f reflect.StructField) string {
+		name := f.Tag.Get(tagName)
+		if i := strings.Index(name, ","); i >= 0 {
+			name = name[:i]
+		}
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+}
+
+// WithClock returns an Option which sets the function returning the current
+// time to functions such as 'past' and 'future'. It is time.Now by default.
+// It panics if now is nil.
+func WithClock(now func() time.Time) Option {
+	if now == nil {
+		panic("validator: invalid clock")
+	}
+	return func(v *Validator) {
+		v.now = now
+	}
+}
+
 // WithFunc returns an Option which adds a new function handler.
 // If a function with same name existed, it will be overriden by the given one.
 // It panics if name is empty or handler is nil.
@@ -87,18 +255,61 @@ func WithFunc(name string, fn Func) Option {
 		panic("validator: invalid handler " + name)
 	}
 	return func(v *Validator) {
-		v.register(name, fn)
+		v.funcs[name] = handler{build: funcBuilder(fn)}
 	}
 }
 
-func (a *Validator) register(name string, fn Func) {
-	a.funcs[name] = fn
+// WithFuncContext returns an Option which adds a new function handler
+// receiving context. If a function with same name existed, it will be
+// overriden by the given one.
+// It panics if name is empty or handler is nil.
+func WithFuncContext(name string, fn FuncContext) Option {
+	if name == "" || fn == nil {
+		panic("validator: invalid handler " + name)
+	}
+	return func(v *Validator) {
+		v.funcs[name] = handler{ctxFn: fn}
+	}
+}
+
+// WithAlias returns an Option which adds alias name for a list of rules
+// in tags, e.g. WithAlias("username", "notempty,min=3,max=32"). The alias
+// can then be used in place of the rules in field tags, but it cannot be
+// negated or used in alternatives. Aliases take precedence over functions
+// with the same name.
+// It panics if name or tags is empty.
+func WithAlias(name, tags string) Option {
+	if name == "" || tags == "" {
+		panic("validator: invalid alias " + name)
+	}
+	return func(v *Validator) {
+		v.aliases[name] = tags
+	}
+}
+
+func (a *Validator) register(name string, b builder) {
+	a.funcs[name] = handler{
+		build:    b,
+		negMsg:   negatedMessages[name],
+		negBound: negatedBounds[name],
+	}
+}
+
+func (a *Validator) registerStruct(name string, b structBuilder) {
+	a.funcs[name] = handler{buildStruct: b, negMsg: negatedMessages[name]}
 }
 
 // Validate validates given value. Value v is usually a pointer to
 // the struct to validate, but it can also be a struct, slice or array.
-func (a *Validator) Validate(v interface{}) (err error) {
-	s := state{validator: a}
+func (a *Validator) Validate(v interface{}) error {
+	return a.ValidateContext(context.Background(), v)
+}
+
+// ValidateContext is like Validate but passes ctx to ValidatableContext
+// values and FuncContext functions. Validation stops when ctx is done and
+// the context error is added to the returned errors.
+func (a *Validator) ValidateContext(ctx context.Context, v interface{}) (err error) {
+	s := state{validator: a, ctx: ctx}
 	defer func() {
 		if r := recover(); r != nil {
 			if rerr, ok := r.(error); ok {
@@ -142,31 +353,95 @@ func (a *Validator) getFields(rt reflect.Type) []field {
 				continue
 			}
 		}
-		fields = append(fields, field{
-			idx:  i,
-			name: ft.Name,
-			tags: tags,
-		})
+		f := field{
+			idx:       i,
+			name:      a.fieldName(ft),
+			anonymous: ft.Anonymous,
+		}
+		if tags != "" {
+			f.rules = a.compileRules(rt, ft, tags)
+			for j := range f.rules {
+				if f.rules[j].omitEmpty {
+					f.omitEmpty = true
+				}
+			}
+		}
+		fields = append(fields, f)
 	}
 	a.fieldCache.save(rt, fields)
 	return fields
 }
 
+// fieldName returns the name of field f used in errors.
+func (a *Validator) fieldName(f reflect.StructField) string {
+	if a.nameFunc != nil {
+		if name := a.nameFunc(f); name != "" {
+			return name
+		}
+	}
+	return f.Name
+}
+
 func supported(rt reflect.Type) bool {
 	switch rt.Kind() {
 	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map, reflect.Ptr, reflect.Interface:
 		return true
 	}
-	return rt.Implements(validatableType)
+	return rt.Implements(validatableType) || rt.Implements(validatableContextType)
 }
 
 type state struct {
 	validator *Validator
+	ctx       context.Context
+	done      bool // ctx is done
+
+	path    []pathElem
+	errors  []error
+	scratch []byte // buffer to build field paths
+}
+
+// pathElem is an element in the path to the value being validated.
+type pathElem struct {
+	name  string
+	index bool // name is a slice index or map key
+}
+
+func (s *state) push(name string, index bool) {
+	s.path = append(s.path, pathElem{name: name, index: index})
+}
+
+func (s *state) pop() {
+	s.path = s.path[:len(s.path)-1]
+}
 
-	errors []error
+// fieldPath returns full path of the field name in current value.
+func (s *state) fieldPath(name string) string {
+	if len(s.path) == 0 {
+		return name
+	}
+	buf := s.scratch[:0]
+	for _, e := range s.path {
+		if e.index {
+			buf = append(buf, '[')
+			buf = append(buf, e.name...)
+			buf = append(buf, ']')
+		} else {
+			if len(buf) > 0 {
+				buf = append(buf, '.')
+			}
+			buf = append(buf, e.name...)
+		}
+	}
+	buf = append(buf, '.')
+	buf = append(buf, name...)
+	s.scratch = buf
+	return string(buf)
 }
 
 func (s *state) validateValue(rv reflect.Value) {
+	if s.isDone() {
+		return
+	}
 	// Call Validate method if this value implements Validatable
 	s.validateValidatable(rv)
 
@@ -197,13 +472,27 @@ func (s *state) validateStruct(rv reflect.Value) {
 	fields := s.validator.getFields(rt)
 	n := len(fields)
 	for i := 0; i < n; i++ {
+		if s.isDone() {
+			return
+		}
 		ft := &fields[i]
 		fv := rv.Field(ft.idx)
-		if ft.tags != "" {
+		if len(ft.rules) > 0 {
 			// Validate this field
-			s.validateField(fv, ft.name, ft.tags)
+			s.validateField(rv, fv, ft)
+		}
+		if ft.omitEmpty && isEmpty(fv) {
+			continue
+		}
+		// Fields of embedded structs are promoted so they are not
+		// prefixed with the embedded type name.
+		if ft.anonymous {
+			s.validateValue(fv)
+		} else {
+			s.push(ft.name, false)
+			s.validateValue(fv)
+			s.pop()
 		}
-		s.validateValue(fv)
 	}
 }
 
@@ -215,7 +504,9 @@ func (s *state) validateSlice(rv reflect.Value) {
 	n := rv.Len()
 	for i := 0; i < n; i++ {
 		fv := rv.Index(i)
+		s.push(strconv.Itoa(i), true)
 		s.validateValue(fv)
+		s.pop()
 	}
 }
 
@@ -229,30 +520,143 @@ func (s *state) validateMap(rv reflect.Value) {
 	}
 	for _, k := range rv.MapKeys() {
 		fv := rv.MapIndex(k)
+		s.push(fmt.Sprint(k.Interface()), true)
 		s.validateValue(fv)
+		s.pop()
 	}
 }
 
-func (s *state) validateField(fv reflect.Value, name, tags string) {
-	for tags != "" {
-		var tag string
-		i := strings.Index(tags, ",")
-		if i < 0 {
-			tag = tags
-			tags = ""
+func (s *state) validateField(rv, fv reflect.Value, ft *field) {
+	s.validateRules(rv, fv, ft.name, s.fieldPath(ft.name), ft.rules)
+}
+
+// validateRules validates value fv of field name in struct rv. The value
+// is either the field or its element at given path.
+func (s *state) validateRules(rv, fv reflect.Value, name, path string, rules []rule) {
+	for i := range rules {
+		r := &rules[i]
+		if r.omitEmpty {
+			if isEmpty(fv) {
+				return
+			}
+			continue
+		}
+		if r.dive && r.err == nil {
+			s.validateElements(rv, fv, name, path, r)
+			continue
+		}
+		var err error
+		if len(r.alts) > 0 {
+			err = s.validateAlternatives(rv, fv, name, path, r)
 		} else {
-			tag = tags[:i]
-			tags = tags[i+1:]
+			err = s.validateRule(rv, fv, name, path, r)
 		}
-		fn, param := parseTag(tag)
-		f, ok := s.validator.funcs[fn]
-		if ok {
-			err := f(fv, name, param)
-			if err != nil {
-				s.addError(err)
+		if err != nil && err != errSkip {
+			s.addError(err)
+		}
+	}
+}
+
+// validateRule calls function of rule r. It returns errSkip if the
+// function does not validate the value.
+func (s *state) validateRule(rv, fv reflect.Value, name, path string, r *rule) error {
+	var err error
+	switch {
+	case r.ctxFn != nil:
+		err = r.ctxFn(s.ctx, fv, path, r.param)
+	case r.onStruct:
+		err = r.fn(rv, path, r.param)
+	default:
+		err = r.fn(fv, path, r.param)
+	}
+	if err == errSkip {
+		return err
+	}
+	if ferr, ok := err.(*FieldError); ok {
+		ferr.fill(name, path, r.name, r.param)
+	}
+	if r.negate {
+		return negateError(fv, name, path, r, err)
+	}
+	return err
+}
+
+// validateAlternatives returns nil if any of the alternatives of rule r
+// passes, otherwise an error combining all their errors. Skipped
+// alternatives pass, or are ignored when r is negated. Invalid alternatives
+// of negated rule r are reported as their errors.
+func (s *state) validateAlternatives(rv, fv reflect.Value, name, path string, r *rule) error {
+	msgs := make([]string, 0, len(r.alts))
+	passed := false
+	for i := range r.alts {
+		err := s.validateRule(rv, fv, name, path, &r.alts[i])
+		if r.negate {
+			switch err.(type) {
+			case nil:
+				passed = true
+			case UnsupportedError, *TagError:
+				// Invalid rules are still reported.
+				return err
 			}
+			continue
+		}
+		switch err {
+		case nil, errSkip:
+			return nil
+		}
+		if ferr, ok := err.(*FieldError); ok {
+			msgs = append(msgs, ferr.Message)
 		} else {
-			s.addError(UnsupportedError(fn))
+			msgs = append(msgs, err.Error())
+		}
+	}
+	if r.negate {
+		if passed {
+			return negateError(fv, name, path, r, nil)
+		}
+		return nil
+	}
+	err := newFieldError(fv, path, "", "%s", strings.Join(msgs, " or "))
+	err.fill(name, path, r.name, "")
+	return err
+}
+
+// negateError returns error of negated rule r given error err returned
+// by its validation function.
+func negateError(fv reflect.Value, name, path string, r *rule, err error) error {
+	switch err.(type) {
+	case nil:
+		e := newFieldError(fv, path, r.param, "%s", r.negatedMessage())
+		e.fill(name, path, r.name, r.param)
+		return e
+	case UnsupportedError, *TagError:
+		// Invalid rules are still reported.
+		return err
+	}
+	return nil
+}
+
+// validateElements validates elements of slice, array or map fv with
+// rules of dive rule r. Errors of map keys are reported at the path of
+// their elements followed by "(key)", e.g. "M[k](key)".
+func (s *state) validateElements(rv, fv reflect.Value, name, path string, r *rule) {
+	fv, ok := indirect(fv)
+	if !ok {
+		return
+	}
+	switch fv.Kind() {
+	case reflect.Slice, reflect.Array:
+		n := fv.Len()
+		for i := 0; i < n; i++ {
+			s.validateRules(rv, fv.Index(i), name, path+"["+strconv.Itoa(i)+"]", r.elem)
+		}
+	case reflect.Map:
+		for _, k := range fv.MapKeys() {
+			p := path + "[" + fmt.Sprint(k.Interface()) + "]"
+			if len(r.keys) > 0 {
+				s.validateRules(rv, k, name, p+"(key)", r.keys)
+			}
+			s.validateRules(rv, fv.MapIndex(k), name, p, r.elem)
 		}
 	}
 }
@@ -264,26 +668,34 @@ func (s *state) validateValidatable(rv reflect.Value) {
 	if rv.Kind() == reflect.Ptr && rv.IsNil() {
 		return
 	}
-	if f, ok := rv.Interface().(Validatable); ok {
+	switch f := rv.Interface().(type) {
+	case Validatable:
 		err := f.Validate()
 		if err != nil {
 			s.addError(err)
 		}
+	case ValidatableContext:
+		err := f.Validate(s.ctx)
+		if err != nil {
+			s.addError(err)
+		}
 	}
 }
 
-func (s *state) addError(err error) {
-	s.errors = append(s.errors, err)
+// isDone returns true if the context is done, in which case its error
+// is added once.
+func (s *state) isDone() bool {
+	if s.done {
+		return true
+	}
+	if err := s.ctx.Err(); err != nil {
+		s.done = true
+		s.addError(err)
+		return true
+	}
+	return false
 }
 
-// parseTag returns function name and parameter.
-func parseTag(tag string) (name, param string) {
-	i := strings.Index(tag, "=")
-	if i < 0 {
-		name = tag
-	} else {
-		name = tag[:i]
-		param = tag[i+1:]
-	}
-	return
+func (s *state) addError(err error) {
+	s.errors = append(s.errors, err)
 }
@@ -1,10 +1,12 @@
 package validator
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 )
 
 var errNOK = errors.New("nok")
@@ -28,6 +30,22 @@ func intPtr(v int) *int {
 	return &v
 }
 
+func withBuilder(name string, b builder) Option {
+	return func(v *Validator) {
+		v.register(name, b)
+	}
+}
+
+// withMethod is like withBuilder but for builders which are methods
+// of Validator.
+func withMethod(name string, b func(*Validator, reflect.Type, string) (Func, error)) Option {
+	return func(v *Validator) {
+		v.register(name, func(t reflect.Type, param string) (Func, error) {
+			return b(v, t, param)
+		})
+	}
+}
+
 func newTestValidator() *Validator {
 	return New(WithFunc("ok", ok), WithFunc("nok", nok))
 }
@@ -350,6 +368,519 @@ func TestValidatable(t *testing.T) {
 	assertNOK(t, err, "1", "nok", "C", "2", "nok", "3", "nok", "EC", "EE")
 }
 
+func TestFieldError(t *testing.T) {
+	s := struct {
+		A string `valid:"notempty"`
+		B int    `valid:"min=10"`
+	}{
+		B: 9,
+	}
+	v := New(withBuilder("notempty", notEmpty), withMethod("min", (*Validator).min))
+	err := v.Validate(&s)
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	errs := err.(Errors)
+	if len(errs) != 2 {
+		t.Fatalf("unexpected errors length: %+v; want: %d", err, 2)
+	}
+	e, ok := errs[0].(*FieldError)
+	if !ok {
+		t.Fatalf("unexpected error type: %T", errs[0])
+	}
+	if e.Field != "A" || e.Path != "A" || e.Rule != "notempty" || e.Param != "" || e.Value != "" {
+		t.Fatalf("unexpected error: %#v", e)
+	}
+	e, ok = errs[1].(*FieldError)
+	if !ok {
+		t.Fatalf("unexpected error type: %T", errs[1])
+	}
+	if e.Field != "B" || e.Path != "B" || e.Rule != "min" || e.Param != "10" || e.Value != 9 {
+		t.Fatalf("unexpected error: %#v", e)
+	}
+	if e.Error() != "B must not be less than 10 (was 9)" {
+		t.Fatalf("unexpected error: %v", e)
+	}
+}
+
+func TestPath(t *testing.T) {
+	type s1 struct {
+		A int `valid:"nok=A"`
+	}
+	type s2 struct {
+		s1
+		B []*s1
+		C map[string]s1
+		D *struct {
+			E [2]s1
+		}
+		F interface{}
+	}
+	s := s2{
+		B: []*s1{nil, &s1{}},
+		C: map[string]s1{"c": s1{}},
+		D: &struct {
+			E [2]s1
+		}{},
+		F: &s1{},
+	}
+	var paths []string
+	v := New(WithFunc("nok", func(rv reflect.Value, name, param string) error {
+		paths = append(paths, name)
+		return nil
+	}))
+	err := v.Validate(&s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"A", "B[1].A", "C[c].A", "D.E[0].A", "D.E[1].A", "F.A"}
+	if fmt.Sprint(paths) != fmt.Sprint(expected) {
+		t.Fatalf("unexpected paths: %v; want: %v", paths, expected)
+	}
+}
+
+func TestFieldErrorPath(t *testing.T) {
+	type s1 struct {
+		A int `valid:"min=1"`
+	}
+	s := struct {
+		B []s1
+	}{
+		B: []s1{s1{1}, s1{0}},
+	}
+	v := New(withMethod("min", (*Validator).min))
+	err := v.Validate(&s)
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	e := err.(Errors)[0].(*FieldError)
+	if e.Field != "A" || e.Path != "B[1].A" {
+		t.Fatalf("unexpected error: %#v", e)
+	}
+	if e.Error() != "B[1].A must not be less than 1 (was 0)" {
+		t.Fatalf("unexpected error: %v", e)
+	}
+}
+
+func TestNameTag(t *testing.T) {
+	type s1 struct {
+		A int `json:"a,omitempty" valid:"nok=A"`
+		B int `json:"-" valid:"nok=B"`
+		C int `json:",omitempty" valid:"nok=C"`
+		D int `valid:"nok=D"`
+	}
+	s := struct {
+		E []s1 `json:"e"`
+	}{
+		E: []s1{s1{}},
+	}
+	var paths []string
+	v := New(WithNameTag("json"), WithFunc("nok", func(rv reflect.Value, name, param string) error {
+		paths = append(paths, name)
+		return nil
+	}))
+	err := v.Validate(&s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"e[0].a", "e[0].B", "e[0].C", "e[0].D"}
+	if fmt.Sprint(paths) != fmt.Sprint(expected) {
+		t.Fatalf("unexpected paths: %v; want: %v", paths, expected)
+	}
+}
+
+type ctxKey struct{}
+
+type vContext string
+
+func (v vContext) Validate(ctx context.Context) error {
+	return fmt.Errorf("%v%v", v, ctx.Value(ctxKey{}))
+}
+
+func TestValidateContext(t *testing.T) {
+	s := struct {
+		A int `valid:"ctx"`
+		B vContext
+		C []vContext
+	}{
+		B: "B",
+		C: []vContext{"C"},
+	}
+	v := New(WithFuncContext("ctx", func(ctx context.Context, rv reflect.Value, name, param string) error {
+		return fmt.Errorf("%v%v", name, ctx.Value(ctxKey{}))
+	}))
+	ctx := context.WithValue(context.Background(), ctxKey{}, "!")
+	err := v.ValidateContext(ctx, &s)
+	assertNOK(t, err, "A!", "B!", "C!")
+}
+
+func TestValidateContextCanceled(t *testing.T) {
+	s := struct {
+		A int `valid:"cancel"`
+		B int `valid:"nok"`
+		C []vContext
+	}{
+		C: []vContext{"C"},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	v := New(WithFunc("nok", nok), WithFunc("cancel", func(rv reflect.Value, name, param string) error {
+		cancel()
+		return nil
+	}))
+	err := v.ValidateContext(ctx, &s)
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	errs := err.(Errors)
+	if len(errs) != 1 || errs[0] != context.Canceled {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+}
+
+func TestOmitEmpty(t *testing.T) {
+	type s1 struct {
+		A int
+	}
+	type s2 struct {
+		B int `valid:"nok=HB"`
+		C int
+	}
+	s := struct {
+		A string            `valid:"omitempty,nok=A"`
+		B int               `valid:"omitempty,nok=B"`
+		C []int             `valid:"omitempty,nok=C"`
+		D map[string]string `valid:"omitempty,nok=D"`
+		E *string           `valid:"omitempty,nok=E"`
+		F s1                `valid:"omitempty,nok=F"`
+		G float64           `valid:"nok=G1,omitempty,nok=G2"`
+		H s2                `valid:"omitempty"`
+	}{}
+	v := newTestValidator()
+	err := v.Validate(&s)
+	assertNOK(t, err, "G1")
+
+	s.A = "a"
+	s.B = 1
+	s.C = []int{}
+	s.D = map[string]string{"d": ""}
+	s.E = strPtr("")
+	s.F.A = 1
+	s.G = 1
+	s.H.C = 1
+	err = v.Validate(&s)
+	assertNOK(t, err, "A", "B", "D", "E", "F", "G1", "G2", "HB")
+}
+
+func TestDive(t *testing.T) {
+	s := struct {
+		A []string            `valid:"nok=A,dive,omitempty,nok"`
+		B *[2]int             `valid:"dive,nok"`
+		C map[string]int      `valid:"dive,keys,nok=K,endkeys,nok=V"`
+		D [][]int             `valid:"dive,dive,nok"`
+		E map[string][]string `valid:"dive,dive,nok"`
+		F []int               `valid:"dive"`
+	}{
+		A: []string{"", "a"},
+		C: map[string]int{"c": 1},
+		D: [][]int{{}, {1, 2}},
+		E: map[string][]string{"e": {"x"}},
+		F: []int{1},
+	}
+	var paths []string
+	v := New(WithFunc("nok", func(rv reflect.Value, name, param string) error {
+		paths = append(paths, name)
+		return nok(rv, name, param)
+	}))
+	err := v.Validate(&s)
+	assertNOK(t, err, "A", "nok", "K", "V", "nok", "nok", "nok")
+	expected := []string{"A", "A[1]", "C[c](key)", "C[c]", "D[1][0]", "D[1][1]", "E[e][0]"}
+	if fmt.Sprint(paths) != fmt.Sprint(expected) {
+		t.Fatalf("unexpected paths: %v; want: %v", paths, expected)
+	}
+	s.B = &[2]int{}
+	paths = nil
+	err = v.Validate(&s)
+	if len(err.(Errors)) != 9 || paths[2] != "B[0]" || paths[3] != "B[1]" {
+		t.Fatalf("unexpected errors: %v, paths: %v", err, paths)
+	}
+}
+
+func TestDiveFieldError(t *testing.T) {
+	type s1 struct {
+		Tags []string `valid:"max=2,dive,notempty,max=3"`
+	}
+	s := s1{
+		Tags: []string{"a", "", "abcd"},
+	}
+	err := Default().Validate(&s)
+	assertNOK(t, err,
+		"Tags must have length not greater than 2 (was 3)",
+		"Tags[1] must not be empty",
+		"Tags[2] must have length not greater than 3 (was 4)",
+	)
+	e := err.(Errors)[1].(*FieldError)
+	if e.Field != "Tags" || e.Path != "Tags[1]" || e.Rule != "notempty" {
+		t.Fatalf("unexpected error: %#v", e)
+	}
+	type s2 struct {
+		Labels map[string]string `valid:"dive,keys,max=1,endkeys,max=1"`
+	}
+	err = Default().Validate(&s2{Labels: map[string]string{"ab": "cd"}})
+	assertNOK(t, err,
+		"Labels[ab](key) must have length not greater than 1 (was 2)",
+		"Labels[ab] must have length not greater than 1 (was 2)",
+	)
+	e = err.(Errors)[0].(*FieldError)
+	if e.Field != "Labels" || e.Path != "Labels[ab](key)" || e.Value != "ab" {
+		t.Fatalf("unexpected error: %#v", e)
+	}
+}
+
+func TestDiveCheck(t *testing.T) {
+	type s1 struct {
+		A string         `valid:"dive,notempty"`
+		B map[string]int `valid:"dive,keys,notempty"`
+		C []int          `valid:"dive,eqfield=D"`
+		D []bool         `valid:"dive,min=1"`
+		E []string       `valid:"dive,keys,notempty,endkeys"`
+	}
+	err := Default().Check(s1{})
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	errs := err.(Errors)
+	if len(errs) != 6 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if errs[1].Error() != `rule "dive" of validator.s1.B: missing endkeys` {
+		t.Fatalf("unexpected error: %v", errs[1])
+	}
+	if errs[3].Error() != `rule "min=1" of validator.s1.D: validator: unsupported: bool` {
+		t.Fatalf("unexpected error: %v", errs[3])
+	}
+}
+
+func TestAlternatives(t *testing.T) {
+	s := struct {
+		A string `valid:"nok=A1|ok"`
+		B string `valid:"nok=B1|nok=B2"`
+		C string `valid:"notempty|min=3|nefield=A"`
+		D []int  `valid:"dive,min=10|max=1"`
+	}{
+		C: "ab",
+		D: []int{0, 5, 10},
+	}
+	v := New(DefaultOption(), WithFunc("ok", ok), WithFunc("nok", nok))
+	err := v.Validate(&s)
+	assertNOK(t, err,
+		"B B1 or B2",
+		"D[1] must not be less than 10 (was 5) or must not be greater than 1 (was 5)",
+	)
+	e := err.(Errors)[1].(*FieldError)
+	if e.Field != "D" || e.Rule != "min=10|max=1" || e.Param != "" || e.Value != 5 {
+		t.Fatalf("unexpected error: %#v", e)
+	}
+	s.C = ""
+	err = v.Validate(&s)
+	if len(err.(Errors)) != 3 || err.(Errors)[1].Error() != "C must not be empty or must have length not less than 3 (was 0) or must not be equal to A" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	type s1 struct {
+		A int `valid:"min=1|mni=2"`
+	}
+	err = v.Check(s1{})
+	if err == nil || len(err.(Errors)) != 1 {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestNegation(t *testing.T) {
+	s := struct {
+		A string `valid:"!notempty"`
+		B string `valid:"!nok"`
+		C int    `valid:"!min=1,!max=10"`
+		D string `valid:"!eqfield=A"`
+		E int    `valid:"not(min=3|max=1)"`
+		F int    `valid:"!ok|ok"`
+		G int    `valid:"not(ok|!nok)"`
+		H int    `valid:"not(ok)"`
+		I int    `valid:"!mni=1"`
+		J []int  `valid:"dive,!notempty"`
+		K int    `valid:"not(!nok)"`
+		L int    `valid:"not(min=3)|not(max=10)"`
+	}{
+		A: "a",
+		C: 5,
+		D: "a",
+		E: 3,
+		J: []int{0, 1},
+		L: 5,
+	}
+	v := New(DefaultOption(), WithFunc("ok", ok), WithFunc("nok", nok))
+	err := v.Validate(&s)
+	assertNOK(t, err,
+		"A must be empty",
+		"C must be less than 1",
+		"C must be greater than 10",
+		"D must not be equal to A",
+		"E must be less than 3 and must be greater than 1",
+		"G must not satisfy ok and must satisfy nok",
+		"H must not satisfy ok",
+		"validator: unsupported: mni",
+		"J[1] must be empty",
+		"nok",
+		"L must be less than 3 or must be greater than 10",
+	)
+	e := err.(Errors)[1].(*FieldError)
+	if e.Rule != "!min" || e.Param != "1" || e.Value != 5 {
+		t.Fatalf("unexpected error: %#v", e)
+	}
+	e = err.(Errors)[4].(*FieldError)
+	if e.Rule != "not(min=3|max=1)" || e.Param != "" {
+		t.Fatalf("unexpected error: %#v", e)
+	}
+}
+
+func TestNegationSkipped(t *testing.T) {
+	s := struct {
+		A *string `valid:"!email"`
+		B *int    `valid:"!min=3,not(max=1|gt=5)"`
+		C string  `valid:"!min=2000-01-01"`
+		D *string `valid:"!eqfield=C"`
+	}{
+		C: "abc",
+	}
+	err := Default().Validate(&s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestNegationInvalid(t *testing.T) {
+	s := struct {
+		A string `valid:"not(foo|bar)"`
+		B string `valid:"not(max=1|min=x)"`
+	}{
+		A: "x",
+		B: "x",
+	}
+	err := Default().Validate(&s)
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	errs := err.(Errors)
+	if len(errs) != 2 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if errs[0] != UnsupportedError("foo") {
+		t.Fatalf("unexpected error: %#v", errs[0])
+	}
+	if e, ok := errs[1].(*TagError); !ok || e.Rule != "min" || e.Param != "x" {
+		t.Fatalf("unexpected error: %#v", errs[1])
+	}
+	type s1 struct {
+		A string `valid:"not(min=1|max=2)|len=1"`
+	}
+	err = Default().Check(s1{})
+	if err == nil || err.Error() != `rule "not(min=1|max=2)" of validator.s1.A: nested alternatives` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestNegationLength(t *testing.T) {
+	s := struct {
+		A string    `valid:"!min=3"`
+		B []int     `valid:"!max=1,!between=1 3"`
+		C time.Time `valid:"!min=2000-01-01T00:00:00Z"`
+		D string    `valid:"not(len=3|gt=5)"`
+		E int       `valid:"!between=1 3"`
+	}{
+		A: "abc",
+		B: []int{1},
+		C: time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC),
+		D: "abc",
+		E: 2,
+	}
+	err := Default().Validate(&s)
+	assertNOK(t, err,
+		"A must have length less than 3",
+		"B must have length greater than 1",
+		"B must have length not between 1 and 3",
+		"C must be before 2000-01-01T00:00:00Z",
+		"D must not have length 3 and must have length not greater than 5",
+		"E must not be between 1 and 3",
+	)
+}
+
+func TestAlias(t *testing.T) {
+	s := struct {
+		A string   `valid:"username"`
+		B []string `valid:"notempty,dive,username"`
+		C string   `valid:"name"`
+	}{
+		A: "ab",
+		B: []string{"abcd", ""},
+		C: "abcd",
+	}
+	v := New(DefaultOption(),
+		WithAlias("username", "notempty,min=3,max=4"),
+		WithAlias("name", "username,!eqfield=A"))
+	err := v.Validate(&s)
+	assertNOK(t, err,
+		"A must have length not less than 3 (was 2)",
+		"B[1] must not be empty",
+		"B[1] must have length not less than 3 (was 0)",
+	)
+	e := err.(Errors)[0].(*FieldError)
+	if e.Rule != "min" || e.Param != "3" {
+		t.Fatalf("unexpected error: %#v", e)
+	}
+}
+
+func TestAliasCycle(t *testing.T) {
+	type s1 struct {
+		A string `valid:"notempty,a"`
+		B string `valid:"c"`
+	}
+	v := New(DefaultOption(),
+		WithAlias("a", "notempty,b"),
+		WithAlias("b", "max=1,a"),
+		WithAlias("c", "notempty"))
+	err := v.Check(s1{})
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	errs := err.(Errors)
+	if len(errs) != 1 || errs[0].Error() != `rule "a" of validator.s1.A: alias cycle a -> b -> a` {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	err = v.Validate(&s1{})
+	assertNOK(t, err, errs[0].Error(), "B must not be empty")
+}
+
+func TestAliasNegation(t *testing.T) {
+	type s1 struct {
+		A string `valid:"!username"`
+		B string `valid:"not(username)"`
+		C string `valid:"username|email"`
+	}
+	v := New(DefaultOption(), WithAlias("username", "notempty,min=3"))
+	err := v.Check(s1{})
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	errs := err.(Errors)
+	if len(errs) != 3 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	for _, e := range errs {
+		if e.(*TagError).Err.Error() != "alias username cannot be negated or used in alternatives" {
+			t.Fatalf("unexpected error: %v", e)
+		}
+	}
+}
+
 func assertNOK(t *testing.T, err error, msg ...string) {
 	if err == nil {
 		t.Fatal("error expected")
@@ -444,3 +975,42 @@ func BenchmarkWithTag(b *testing.B) {
 		}
 	})
 }
+
+func BenchmarkValidNested(b *testing.B) {
+	b.ReportAllocs()
+	v := newTestValidator()
+
+	type s1 struct {
+		A int  `valid:"ok"`
+		B uint `valid:"ok"`
+	}
+	type s2 struct {
+		B string `valid:"ok"`
+		C *s1
+		E []s1
+	}
+	s := s2{
+		B: "ok",
+		C: &s1{1, 0},
+		E: []s1{{3, 0}, {4, 0}},
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.Validate(&s)
+	}
+}
+
+func BenchmarkValidTopLevel(b *testing.B) {
+	b.ReportAllocs()
+	v := Default()
+
+	s := struct {
+		A string `valid:"notempty,max=10"`
+		B int    `valid:"min=1"`
+		C *int   `valid:"omitempty,gt=0"`
+	}{"a", 1, intPtr(2)}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.Validate(&s)
+	}
+}